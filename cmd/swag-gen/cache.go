@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neglet30/swag-gen/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cachePath string
+	cacheDir  string
+	cacheTTL  time.Duration
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "管理解析缓存",
+	Long: `查看或清除 init 命令使用的解析缓存。
+
+示例:
+  swag-gen cache stats -p ./api
+  swag-gen cache clear -p ./api`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "显示解析缓存的统计信息",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "清除解析缓存",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+func init() {
+	cacheCmd.PersistentFlags().StringVarP(&cachePath, "path", "p", "./", "API 源代码路径")
+	cacheCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "解析缓存目录，相对于源代码路径，默认 "+parser.DefaultCacheDir)
+	cacheStatsCmd.Flags().DurationVar(&cacheTTL, "cache-ttl", time.Hour, "判断缓存项是否过期的有效期，0 表示不过期")
+
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	stats, err := newParseCache(cachePath, cacheDir, cacheTTL).Stats()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "缓存目录: %s\n", stats.Dir)
+	fmt.Fprintf(out, "  缓存项: %d\n", stats.Entries)
+	fmt.Fprintf(out, "  已过期: %d\n", stats.Expired)
+	fmt.Fprintf(out, "  大小: %s\n", formatSize(stats.Size))
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	cache := newParseCache(cachePath, cacheDir, 0)
+	if err := cache.Clear(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ 已清除缓存: %s\n", cache.Dir())
+	return nil
+}
+
+// newParseCache 创建项目的解析缓存，缓存项与当前版本绑定
+func newParseCache(projectPath, dir string, ttl time.Duration) *parser.Cache {
+	return parser.NewCache(parser.CacheDir(projectPath, dir), version, ttl)
+}
+
+// formatSize 把字节数格式化为易读的大小
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"time"
 
 	"github.com/neglet30/swag-gen/pkg/config"
 	"github.com/neglet30/swag-gen/pkg/logger"
@@ -21,6 +25,30 @@ var (
 	initVersion     string
 	initDescription string
 	initFormat      string
+	initDiscover    bool
+	initFrameworks  []string
+	initGeneralInfo string
+	initSecurity    string
+	initAccept      []string
+	initProduce     []string
+	initAllOf       bool
+	initTagOrder    string
+	initTagGroups   []string
+	initStrict      bool
+	initConcurrent  int
+	initTimeout     time.Duration
+	initNoCache     bool
+	initCacheDir    string
+	initCacheTTL    time.Duration
+	initInclude     []string
+	initExclude     []string
+	initGitIgnore   bool
+	initGenerated   bool
+	initTags        []string
+	initGOOS        string
+	initGOARCH      string
+	initConflicts   string
+	initOperationID string
 )
 
 var initCmd = &cobra.Command{
@@ -41,6 +69,30 @@ func init() {
 	initCmd.Flags().StringVarP(&initVersion, "version", "v", "1.0.0", "API 版本")
 	initCmd.Flags().StringVarP(&initDescription, "description", "d", "", "API 描述")
 	initCmd.Flags().StringVarP(&initFormat, "format", "f", "json", "输出格式 (json 或 yaml)")
+	initCmd.Flags().StringVarP(&initGeneralInfo, "general-info", "g", "", "包含 @title 等通用信息注释的文件，默认自动查找")
+	initCmd.Flags().StringVar(&initSecurity, "security", "", "全局默认的安全需求，语法与 @Security 相同，如 \"BearerAuth || ApiKeyAuth\"")
+	initCmd.Flags().StringSliceVar(&initAccept, "accept", []string{"json"}, "没有 @Accept 注释时请求体的 MIME 类型，支持 json、xml、mpfd 等简写")
+	initCmd.Flags().StringSliceVar(&initProduce, "produce", []string{"json"}, "没有 @Produce 注释时响应的 MIME 类型，支持 json、xml、octet-stream 等简写")
+	initCmd.Flags().BoolVar(&initAllOf, "embedded-allof", false, "嵌入结构体生成 allOf 组合，默认将字段展开到外层结构体")
+	initCmd.Flags().StringVar(&initTagOrder, "tag-order", "declared", "标签顺序：declared 先列出 @tag.name 声明的标签，alpha 按名称排序")
+	initCmd.Flags().StringArrayVar(&initTagGroups, "tag-group", nil, "x-tagGroups 中的标签分组，可重复指定，如 \"账户=User,Admin\"")
+	initCmd.Flags().BoolVar(&initDiscover, "discover-routes", false, "从路由注册代码中发现没有 @Router 注释的路由")
+	initCmd.Flags().StringSliceVar(&initFrameworks, "frameworks", nil, "路由发现使用的框架 (gin、nethttp、chi、echo)，默认全部")
+	initCmd.Flags().IntVar(&initConcurrent, "max-concurrent", 4, "同时解析的文件数，0 表示使用 CPU 核数")
+	initCmd.Flags().DurationVar(&initTimeout, "timeout", 0, "解析项目的超时时间，如 30s、2m，0 表示不限制")
+	initCmd.Flags().StringSliceVar(&initInclude, "include", nil, "只解析匹配的文件，相对于源代码路径的 doublestar 模式，如 \"api/**/*.go\"")
+	initCmd.Flags().StringSliceVar(&initExclude, "exclude", []string{"node_modules", "test", "tests"}, "跳过匹配的文件或目录，不含 / 的模式匹配任意层级的名称")
+	initCmd.Flags().BoolVar(&initGitIgnore, "gitignore", false, "跳过 .gitignore 忽略的文件")
+	initCmd.Flags().BoolVar(&initGenerated, "include-generated", false, "解析带有 \"Code generated ... DO NOT EDIT.\" 标记的生成文件")
+	initCmd.Flags().StringSliceVar(&initTags, "tags", nil, "构建标签，与 go build -tags 相同，如 enterprise,debug")
+	initCmd.Flags().StringVar(&initGOOS, "goos", "", "判断构建约束使用的目标操作系统，默认当前平台")
+	initCmd.Flags().StringVar(&initGOARCH, "goarch", "", "判断构建约束使用的目标架构，默认当前平台")
+	initCmd.Flags().BoolVar(&initNoCache, "no-cache", false, "不使用解析缓存，重新解析所有文件")
+	initCmd.Flags().StringVar(&initCacheDir, "cache-dir", "", "解析缓存目录，相对于源代码路径，默认 "+parser.DefaultCacheDir)
+	initCmd.Flags().DurationVar(&initCacheTTL, "cache-ttl", time.Hour, "解析缓存的有效期，0 表示不过期")
+	initCmd.Flags().StringVar(&initConflicts, "route-conflicts", "error", "方法与路径相同的端点的处理方式：error 报错，first-wins 保留先出现的，last-wins 保留后出现的")
+	initCmd.Flags().StringVar(&initOperationID, "operation-id", "camel", "没有 @ID 注释时 operationId 的生成方式：camel (getUser)、snake (get_user)、package (userGetUser)、none")
+	initCmd.Flags().BoolVar(&initStrict, "strict", false, "解析过程中出现任何错误时以非零状态退出，不写入文档")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -72,37 +124,109 @@ func runInit(cmd *cobra.Command, args []string) error {
 			Description: initDescription,
 		},
 		Parser: config.ParserConfig{
-			EnableCache:   true,
-			CacheTTL:      3600,
-			MaxConcurrent: 4,
-			ExcludeDirs:   []string{"vendor", "node_modules", ".git", "test", "tests"},
+			EnableCache:      !initNoCache,
+			CacheTTL:         int(initCacheTTL / time.Second),
+			CacheDir:         initCacheDir,
+			MaxConcurrent:    initConcurrent,
+			Include:          initInclude,
+			Exclude:          initExclude,
+			GitIgnore:        initGitIgnore,
+			IncludeGenerated: initGenerated,
+			BuildTags:        initTags,
+			GOOS:             initGOOS,
+			GOARCH:           initGOARCH,
+			DiscoverRoutes:   initDiscover,
+			Frameworks:       initFrameworks,
+			GeneralInfo:      initGeneralInfo,
+			Strict:           initStrict,
+			RouteConflicts:   initConflicts,
+			OperationID:      initOperationID,
+		},
+		Swagger: config.SwaggerConfig{
+			Security:       initSecurity,
+			DefaultAccept:  initAccept,
+			DefaultProduce: initProduce,
+			EmbeddedAllOf:  initAllOf,
+			TagOrder:       initTagOrder,
+			TagGroups:      initTagGroups,
 		},
 	}
 
 	// 创建解析器
 	p := parser.NewParser(cfg, log)
+	var cache *parser.Cache
+	if cfg.Parser.EnableCache {
+		cache = newParseCache(initPath, cfg.Parser.CacheDir, time.Duration(cfg.Parser.CacheTTL)*time.Second)
+		p.SetCache(cache)
+	}
+
+	// 解析项目，收到中断信号或超时时停止
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	if initTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, initTimeout)
+		defer cancel()
+	}
 
-	// 解析项目
 	fmt.Println("\n正在解析项目...")
-	endpoints, err := p.ParseProject(initPath)
+	endpoints, err := p.ParseProjectContext(ctx, initPath)
 	if err != nil {
 		return fmt.Errorf("解析项目失败: %w", err)
 	}
 
 	fmt.Printf("✓ 找到 %d 个 API 端点\n", len(endpoints))
 
+	// 删除过期的缓存项，避免缓存目录无限增长
+	if cache != nil {
+		if _, err := cache.Prune(); err != nil {
+			log.Warn("清理解析缓存失败", zap.Error(err))
+		}
+	}
+
 	// 创建 Swagger 构建器
 	fmt.Println("\n正在生成 Swagger 文档...")
 	builder := swagger.NewBuilder(initTitle, initVersion, initDescription)
+	builder.SetTypeRegistry(p.Types())
+	builder.SetEmbeddedAllOf(cfg.Swagger.EmbeddedAllOf)
+	if order, err := swagger.ParseTagOrder(cfg.Swagger.TagOrder); err == nil {
+		builder.SetTagOrder(order)
+	}
+	for _, spec := range cfg.Swagger.TagGroups {
+		if group, err := swagger.ParseTagGroup(spec); err == nil {
+			builder.AddTagGroup(group.Name, group.Tags...)
+		}
+	}
+	builder.SetGeneralInfo(p.GeneralInfo())
+	applyInfoFlags(cmd, builder)
+	builder.SetDefaultMediaTypes(parser.MIMETypes(cfg.Swagger.DefaultAccept...), parser.MIMETypes(cfg.Swagger.DefaultProduce...))
+	if cfg.Swagger.Security != "" {
+		builder.SetDefaultSecurity(parser.ParseSecurity(cfg.Swagger.Security))
+	}
 
-	// 添加所有端点
+	// 添加所有端点，无法写入文档的端点记录为诊断信息
+	diagnostics := p.Diagnostics()
 	for _, endpoint := range endpoints {
 		if err := builder.AddEndpoint(endpoint); err != nil {
-			log.Warn("添加端点失败", zap.String("endpoint", endpoint.Path), zap.Error(err))
-			continue
+			diagnostics = append(diagnostics, parser.Diagnostic{
+				File:     endpoint.File,
+				Line:     endpoint.Line,
+				Tag:      "@Router",
+				Message:  fmt.Sprintf("%s %s 无法写入文档: %v", endpoint.Method, endpoint.Path, err),
+				Severity: parser.SeverityError,
+			})
 		}
 	}
 
+	// 输出诊断信息
+	printDiagnostics(cmd.ErrOrStderr(), diagnostics)
+	if errs := parser.CountSeverity(diagnostics, parser.SeverityError); cfg.Parser.Strict && errs > 0 {
+		return fmt.Errorf("严格模式下解析存在 %d 个错误", errs)
+	}
+	if policy, _ := parser.ParseRoutePolicy(cfg.Parser.RouteConflicts); policy == parser.RoutePolicyError && len(p.RouteConflicts()) > 0 {
+		return fmt.Errorf("存在 %d 个冲突的路由，可以使用 --route-conflicts first-wins 或 last-wins 保留其中一个", len(p.RouteConflicts()))
+	}
+
 	// 构建文档
 	doc := builder.Build()
 
@@ -118,8 +242,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Printf("✓ Swagger 文档已写入: %s\n", filepath.Join(initOutput, "swagger."+getFileExtension(initFormat)))
 
 	// 写入配置文件
-	outputConfig := output.NewConfig(initTitle, initVersion, initDescription)
+	info := doc.Info
+	outputConfig := output.NewConfig(info.Title, info.Version, info.Description)
 	outputConfig.SetParserPath(initPath)
+	outputConfig.Parser.Include = cfg.Parser.Include
+	outputConfig.Parser.Exclude = cfg.Parser.Exclude
 	outputConfig.SetOutputPath(initOutput)
 	outputConfig.SetOutputFormat(initFormat)
 
@@ -130,7 +257,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Printf("✓ 配置文件已写入: %s\n", filepath.Join(initOutput, "swag-gen.yaml"))
 
 	// 写入 README
-	if err := writer.WriteREADME("README.md", initTitle, initDescription); err != nil {
+	if err := writer.WriteREADME("README.md", info.Title, info.Description); err != nil {
 		return fmt.Errorf("写入 README 失败: %w", err)
 	}
 
@@ -167,6 +294,29 @@ func validateInitOptions() error {
 		return fmt.Errorf("API 版本不能为空")
 	}
 
+	// 验证并发数
+	if initConcurrent < 0 {
+		return fmt.Errorf("并发数不能为负数")
+	}
+
+	// 验证路由冲突策略与 operationId 生成方式
+	if _, err := parser.ParseRoutePolicy(initConflicts); err != nil {
+		return err
+	}
+	if _, err := parser.ParseOperationIDStrategy(initOperationID); err != nil {
+		return err
+	}
+
+	// 验证标签顺序与分组
+	if _, err := swagger.ParseTagOrder(initTagOrder); err != nil {
+		return err
+	}
+	for _, spec := range initTagGroups {
+		if _, err := swagger.ParseTagGroup(spec); err != nil {
+			return err
+		}
+	}
+
 	// 验证格式
 	if initFormat != "json" && initFormat != "yaml" && initFormat != "yml" {
 		return fmt.Errorf("输出格式必须是 json 或 yaml")
@@ -175,6 +325,38 @@ func validateInitOptions() error {
 	return nil
 }
 
+// applyInfoFlags 用显式指定的命令行参数覆盖通用信息注释中的标题、版本和描述
+func applyInfoFlags(cmd *cobra.Command, builder *swagger.Builder) {
+	info := builder.GetDocument().Info
+
+	if cmd.Flags().Changed("title") {
+		info.Title = initTitle
+	}
+	if cmd.Flags().Changed("version") {
+		info.Version = initVersion
+	}
+	if cmd.Flags().Changed("description") {
+		info.Description = initDescription
+	}
+
+	builder.SetInfo(info)
+}
+
+// printDiagnostics 逐条输出诊断信息，并汇总错误与警告的数量
+func printDiagnostics(w io.Writer, diagnostics []parser.Diagnostic) {
+	if len(diagnostics) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	for _, d := range diagnostics {
+		fmt.Fprintln(w, d.Error())
+	}
+	fmt.Fprintf(w, "⚠ 发现 %d 个错误，%d 个警告\n",
+		parser.CountSeverity(diagnostics, parser.SeverityError),
+		parser.CountSeverity(diagnostics, parser.SeverityWarning))
+}
+
 // getFileExtension 获取文件扩展名
 func getFileExtension(format string) string {
 	if format == "yaml" || format == "yml" {
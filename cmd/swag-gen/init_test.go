@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/neglet30/swag-gen/pkg/parser"
+	"github.com/neglet30/swag-gen/pkg/swagger"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -362,3 +366,46 @@ func TestInitCommandErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+// TestApplyInfoFlags 测试显式指定的命令行参数覆盖通用信息注释
+func TestApplyInfoFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&initTitle, "title", "API Documentation", "")
+	cmd.Flags().StringVar(&initVersion, "version", "1.0.0", "")
+	cmd.Flags().StringVar(&initDescription, "description", "", "")
+	require.NoError(t, cmd.Flags().Set("version", "3.0.0"))
+
+	builder := swagger.NewBuilder(initTitle, initVersion, initDescription)
+	builder.SetGeneralInfo(&parser.GeneralInfo{Title: "Shop API", Version: "2.0", Description: "商城接口"})
+	applyInfoFlags(cmd, builder)
+
+	info := builder.Build().Info
+	assert.Equal(t, "Shop API", info.Title)
+	assert.Equal(t, "3.0.0", info.Version)
+	assert.Equal(t, "商城接口", info.Description)
+}
+
+// TestPrintDiagnostics 测试诊断信息的输出与汇总
+func TestPrintDiagnostics(t *testing.T) {
+	var buf bytes.Buffer
+	printDiagnostics(&buf, []parser.Diagnostic{
+		{File: "api.go", Line: 5, Column: 1, Tag: "@Param", Message: "格式错误", Severity: parser.SeverityError},
+		{File: "api.go", Line: 7, Column: 1, Tag: "@Accept", Message: "无法识别的 MIME 类型 \"toml\"", Severity: parser.SeverityWarning},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, "api.go:5:1: error: @Param: 格式错误")
+	assert.Contains(t, output, "api.go:7:1: warning: @Accept")
+	assert.Contains(t, output, "1 个错误，1 个警告")
+
+	buf.Reset()
+	printDiagnostics(&buf, nil)
+	assert.Empty(t, buf.String())
+}
+
+// TestFormatSize 测试缓存大小的格式化
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KB", formatSize(1536))
+	assert.Equal(t, "2.0 MB", formatSize(2*1024*1024))
+}
@@ -33,4 +33,5 @@ func init() {
 	// 添加子命令
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(serverCmd)
+	rootCmd.AddCommand(cacheCmd)
 }
@@ -37,9 +37,36 @@ type ProjectConfig struct {
 // ParserConfig 解析器配置
 type ParserConfig struct {
 	EnableCache   bool     `mapstructure:"enable_cache"`
-	CacheTTL      int      `mapstructure:"cache_ttl"`
+	CacheTTL      int      `mapstructure:"cache_ttl"` // 缓存有效期，单位为秒，不大于 0 时不过期
 	MaxConcurrent int      `mapstructure:"max_concurrent"`
 	ExcludeDirs   []string `mapstructure:"exclude_dirs"`
+	// Include 与 Exclude 是相对于项目路径的 doublestar 模式，如 api/**/*.go；
+	// 不含 / 的排除模式匹配任意层级的名称
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
+	// GitIgnore 为 true 时跳过 .gitignore 忽略的文件
+	GitIgnore bool `mapstructure:"gitignore"`
+	// IncludeGenerated 为 true 时解析带有 "Code generated ... DO NOT EDIT." 标记的生成文件
+	IncludeGenerated bool `mapstructure:"include_generated"`
+	// BuildTags、GOOS 与 GOARCH 用于判断文件的构建约束，与 go build 的 -tags、GOOS、GOARCH 相同；
+	// GOOS 与 GOARCH 为空时使用当前平台
+	BuildTags []string `mapstructure:"build_tags"`
+	GOOS      string   `mapstructure:"goos"`
+	GOARCH    string   `mapstructure:"goarch"`
+	// DiscoverRoutes 从路由注册代码中发现没有 @Router 注释的路由
+	DiscoverRoutes bool `mapstructure:"discover_routes"`
+	// Frameworks 路由发现使用的框架：gin、nethttp、chi、echo，为空时使用全部
+	Frameworks []string `mapstructure:"frameworks"`
+	// GeneralInfo 包含 @title 等通用信息注释的文件，相对于项目路径；为空时自动查找
+	GeneralInfo string `mapstructure:"general_info"`
+	// CacheDir 解析结果的缓存目录，相对于项目路径；为空时使用 .swag-gen/cache
+	CacheDir string `mapstructure:"cache_dir"`
+	// Strict 为 true 时，解析过程中出现任何错误级别的诊断信息都视为失败
+	Strict bool `mapstructure:"strict"`
+	// RouteConflicts 是方法与路径相同的端点的处理策略：error、first-wins、last-wins，为空时使用 error
+	RouteConflicts string `mapstructure:"route_conflicts"`
+	// OperationID 是没有 @ID 注释时 operationId 的生成方式：camel、snake、package、none，为空时使用 camel
+	OperationID string `mapstructure:"operation_id"`
 }
 
 // SwaggerConfig Swagger 配置
@@ -48,6 +75,17 @@ type SwaggerConfig struct {
 	DefaultVersion     string `mapstructure:"default_version"`
 	DefaultTitle       string `mapstructure:"default_title"`
 	DefaultDescription string `mapstructure:"default_description"`
+	// Security 是全局默认的安全需求，语法与 @Security 相同，如 "BearerAuth || ApiKeyAuth"
+	Security string `mapstructure:"security"`
+	// DefaultAccept 与 DefaultProduce 是没有 @Accept、@Produce 注释时使用的 MIME 类型，支持 json、mpfd 等简写
+	DefaultAccept  []string `mapstructure:"default_accept"`
+	DefaultProduce []string `mapstructure:"default_produce"`
+	// EmbeddedAllOf 为 true 时嵌入结构体生成 allOf 组合，默认与 encoding/json 一样将字段展开到外层结构体
+	EmbeddedAllOf bool `mapstructure:"embedded_allof"`
+	// TagOrder 是文档中标签的顺序：declared 先列出 @tag.name 声明的标签，alpha 按名称排序
+	TagOrder string `mapstructure:"tag_order"`
+	// TagGroups 是 x-tagGroups 中的标签分组，格式为 "分组名=标签1,标签2"
+	TagGroups []string `mapstructure:"tag_groups"`
 }
 
 // LoggerConfig 日志配置
@@ -115,14 +153,34 @@ func setDefaults(v *viper.Viper) {
 	// 解析器配置
 	v.SetDefault("parser.enable_cache", true)
 	v.SetDefault("parser.cache_ttl", 3600)
+	v.SetDefault("parser.cache_dir", "")
 	v.SetDefault("parser.max_concurrent", 4)
 	v.SetDefault("parser.exclude_dirs", []string{"vendor", "node_modules", ".git", "test", "tests"})
+	v.SetDefault("parser.include", []string{})
+	v.SetDefault("parser.exclude", []string{})
+	v.SetDefault("parser.gitignore", false)
+	v.SetDefault("parser.include_generated", false)
+	v.SetDefault("parser.build_tags", []string{})
+	v.SetDefault("parser.goos", "")
+	v.SetDefault("parser.goarch", "")
+	v.SetDefault("parser.discover_routes", false)
+	v.SetDefault("parser.frameworks", []string{})
+	v.SetDefault("parser.general_info", "")
+	v.SetDefault("parser.strict", false)
+	v.SetDefault("parser.route_conflicts", "error")
+	v.SetDefault("parser.operation_id", "camel")
 
 	// Swagger 配置
 	v.SetDefault("swagger.version", "3.0.0")
 	v.SetDefault("swagger.default_version", "1.0.0")
 	v.SetDefault("swagger.default_title", "API Documentation")
 	v.SetDefault("swagger.default_description", "Generated by swag-gen")
+	v.SetDefault("swagger.security", "")
+	v.SetDefault("swagger.default_accept", []string{"json"})
+	v.SetDefault("swagger.default_produce", []string{"json"})
+	v.SetDefault("swagger.embedded_allof", false)
+	v.SetDefault("swagger.tag_order", "declared")
+	v.SetDefault("swagger.tag_groups", []string{})
 
 	// 日志配置
 	v.SetDefault("logger.level", "info")
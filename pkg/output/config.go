@@ -24,6 +24,7 @@ type ProjectConfig struct {
 // ParserConfig represents parser configuration.
 type ParserConfig struct {
 	Path    string   `yaml:"path"`
+	Include []string `yaml:"include,omitempty"`
 	Exclude []string `yaml:"exclude,omitempty"`
 }
 
@@ -6,7 +6,6 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"go.uber.org/zap"
@@ -15,15 +14,29 @@ import (
 // ASTParser 代表 AST 解析器
 type ASTParser struct {
 	logger *zap.Logger
+	fset   *token.FileSet
+	finder *FileFinder
 }
 
 // NewASTParser 创建一个新的 AST 解析器
 func NewASTParser(logger *zap.Logger) *ASTParser {
 	return &ASTParser{
 		logger: logger,
+		fset:   token.NewFileSet(),
+		finder: &FileFinder{},
 	}
 }
 
+// SetFileFinder 设置 ParseDirectory 查找文件使用的规则
+func (ap *ASTParser) SetFileFinder(finder *FileFinder) {
+	ap.finder = finder
+}
+
+// FileSet 返回解析所有文件时共用的 FileSet，用于把位置换算为行号
+func (ap *ASTParser) FileSet() *token.FileSet {
+	return ap.fset
+}
+
 // ParseFile 解析单个文件的 AST
 func (ap *ASTParser) ParseFile(filePath string) (*ast.File, error) {
 	ap.logger.Debug("解析 AST", zap.String("file", filePath))
@@ -35,11 +48,8 @@ func (ap *ASTParser) ParseFile(filePath string) (*ast.File, error) {
 		return nil, fmt.Errorf("读取文件失败: %w", err)
 	}
 
-	// 创建 FileSet
-	fset := token.NewFileSet()
-
 	// 解析 AST
-	astFile, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	astFile, err := parser.ParseFile(ap.fset, filePath, content, parser.ParseComments)
 	if err != nil {
 		ap.logger.Error("解析 AST 失败", zap.String("file", filePath), zap.Error(err))
 		return nil, fmt.Errorf("解析 AST 失败: %w", err)
@@ -52,41 +62,20 @@ func (ap *ASTParser) ParseFile(filePath string) (*ast.File, error) {
 func (ap *ASTParser) ParseDirectory(dirPath string) (map[string]*ast.File, error) {
 	ap.logger.Info("解析目录", zap.String("path", dirPath))
 
-	files := make(map[string]*ast.File)
-
-	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// 跳过目录
-		if info.IsDir() {
-			// 跳过 vendor 和 test 目录
-			if info.Name() == "vendor" || info.Name() == "test" || strings.HasPrefix(info.Name(), ".") {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-
-		// 只处理 Go 文件
-		if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
-			return nil
-		}
+	paths, err := ap.finder.Find(dirPath)
+	if err != nil {
+		ap.logger.Error("解析目录失败", zap.String("path", dirPath), zap.Error(err))
+		return nil, fmt.Errorf("解析目录失败: %w", err)
+	}
 
-		// 解析文件
+	files := make(map[string]*ast.File, len(paths))
+	for _, path := range paths {
 		astFile, err := ap.ParseFile(path)
 		if err != nil {
 			ap.logger.Warn("解析文件失败", zap.String("file", path), zap.Error(err))
-			return nil
+			continue
 		}
-
 		files[path] = astFile
-		return nil
-	})
-
-	if err != nil {
-		ap.logger.Error("解析目录失败", zap.String("path", dirPath), zap.Error(err))
-		return nil, fmt.Errorf("解析目录失败: %w", err)
 	}
 
 	ap.logger.Info("目录解析完成", zap.Int("files", len(files)))
@@ -0,0 +1,275 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheDir 是默认的缓存目录，相对于项目路径
+const DefaultCacheDir = ".swag-gen/cache"
+
+// cacheFormat 是缓存内容的格式版本，格式变化后旧的缓存项自动失效
+const cacheFormat = "2"
+
+// cacheKeyPattern 匹配缓存键，即 SHA-256 哈希的十六进制表示
+var cacheKeyPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Cache 代表解析结果的磁盘缓存。缓存项以文件路径、包路径、文件内容和工具版本的哈希为键，
+// 文件内容或工具版本变化后自动失效
+type Cache struct {
+	dir     string
+	version string
+	ttl     time.Duration
+	now     func() time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// CacheStats 代表缓存目录的统计信息
+type CacheStats struct {
+	Dir     string
+	Entries int
+	Expired int // 超过有效期或由其他版本写入的缓存项
+	Size    int64
+}
+
+// cacheEntry 代表一个缓存文件的内容
+type cacheEntry struct {
+	Version   string       `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	Result    *ParseResult `json:"result"`
+}
+
+// NewCache 创建一个新的缓存，ttl 不大于 0 时缓存项不会过期
+func NewCache(dir, version string, ttl time.Duration) *Cache {
+	return &Cache{
+		dir:     dir,
+		version: version,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// CacheDir 返回项目的缓存目录，dir 为空时使用 DefaultCacheDir，相对路径相对于项目路径
+func CacheDir(projectPath, dir string) string {
+	if dir == "" {
+		dir = DefaultCacheDir
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(projectPath, filepath.FromSlash(dir))
+}
+
+// Dir 返回缓存目录
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Hits 返回本次运行中缓存命中的次数
+func (c *Cache) Hits() int64 {
+	return c.hits.Load()
+}
+
+// Misses 返回本次运行中缓存未命中的次数
+func (c *Cache) Misses() int64 {
+	return c.misses.Load()
+}
+
+// Key 返回文件解析结果的缓存键
+func (c *Cache) Key(filePath, pkgPath string, content []byte) string {
+	h := sha256.New()
+	for _, part := range []string{cacheFormat, c.version, filePath, pkgPath} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get 读取缓存的解析结果，缓存项不存在、已过期或版本不一致时返回 false
+func (c *Cache) Get(key string) (*ParseResult, bool) {
+	entry, err := c.readEntry(c.path(key))
+	if err != nil || !c.valid(entry) {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	return entry.Result, true
+}
+
+// Put 写入解析结果。先写入临时文件再重命名，并发写入同一个键时不会读到不完整的内容
+func (c *Cache) Put(key string, result *ParseResult) error {
+	data, err := json.Marshal(cacheEntry{
+		Version:   c.version,
+		CreatedAt: c.now(),
+		Result:    result,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化缓存失败: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	return nil
+}
+
+// Stats 统计缓存目录中的缓存项，目录不存在时返回空的统计信息
+func (c *Cache) Stats() (CacheStats, error) {
+	stats := CacheStats{Dir: c.dir}
+
+	err := c.walk(func(path string, info fs.FileInfo) {
+		stats.Entries++
+		stats.Size += info.Size()
+
+		if entry, err := c.readEntry(path); err != nil || !c.valid(entry) {
+			stats.Expired++
+		}
+	})
+	if err != nil {
+		return stats, fmt.Errorf("统计缓存失败: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Prune 删除过期或由其他版本写入的缓存项，返回删除的数量
+func (c *Cache) Prune() (int, error) {
+	removed := 0
+
+	err := c.walk(func(path string, info fs.FileInfo) {
+		if entry, err := c.readEntry(path); err == nil && c.valid(entry) {
+			return
+		}
+		if os.Remove(path) == nil {
+			removed++
+		}
+	})
+	if err != nil {
+		return removed, fmt.Errorf("清理缓存失败: %w", err)
+	}
+
+	return removed, nil
+}
+
+// Clear 删除所有缓存项。缓存目录中的其他文件保留，分片目录与缓存目录为空时一并删除
+func (c *Cache) Clear() error {
+	var removeErr error
+	shards := make(map[string]bool)
+
+	err := c.walk(func(path string, info fs.FileInfo) {
+		if err := os.Remove(path); err != nil && removeErr == nil {
+			removeErr = err
+		}
+		shards[filepath.Dir(path)] = true
+	})
+	if err == nil {
+		err = removeErr
+	}
+	if err != nil {
+		return fmt.Errorf("清除缓存失败: %w", err)
+	}
+
+	// 目录不为空时删除失败，说明其中有不属于缓存的文件
+	for shard := range shards {
+		os.Remove(shard)
+	}
+	os.Remove(c.dir)
+	return nil
+}
+
+// path 返回缓存项的文件路径，按键的前两位分目录，避免单个目录中文件过多
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// valid 判断缓存项是否由当前版本写入且没有过期
+func (c *Cache) valid(entry *cacheEntry) bool {
+	if entry.Version != c.version || entry.Result == nil {
+		return false
+	}
+	return c.ttl <= 0 || c.now().Sub(entry.CreatedAt) < c.ttl
+}
+
+// readEntry 读取一个缓存文件
+func (c *Cache) readEntry(path string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// walk 遍历缓存目录中的缓存文件。只访问 path 写入的 <键的前两位>/<键>.json，
+// 缓存目录指向项目目录时，其中的其他文件不会被统计或删除
+func (c *Cache) walk(fn func(path string, info fs.FileInfo)) error {
+	shards, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, shard := range shards {
+		if !shard.IsDir() || len(shard.Name()) != 2 {
+			continue
+		}
+
+		dir := filepath.Join(c.dir, shard.Name())
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			key, ok := strings.CutSuffix(entry.Name(), ".json")
+			if !ok || entry.IsDir() || !cacheKeyPattern.MatchString(key) || key[:2] != shard.Name() {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			fn(filepath.Join(dir, entry.Name()), info)
+		}
+	}
+	return nil
+}
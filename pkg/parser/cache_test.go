@@ -0,0 +1,281 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCacheGetPut(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir, "1.0.0", time.Hour)
+
+	key := cache.Key("api.go", "example.com/app/api", []byte("package api"))
+	assert.NotEqual(t, key, cache.Key("api.go", "example.com/app/api", []byte("package api // changed")))
+	assert.NotEqual(t, key, NewCache(dir, "2.0.0", time.Hour).Key("api.go", "example.com/app/api", []byte("package api")))
+
+	_, ok := cache.Get(key)
+	assert.False(t, ok)
+
+	result := &ParseResult{
+		Endpoints: []*Endpoint{{
+			Method:    "GET",
+			Path:      "/users",
+			File:      "api.go",
+			Line:      7,
+			Responses: map[string]Response{"200": {StatusCode: "200", Schema: &Schema{Ref: "example.com/app/model.User"}}},
+		}},
+		Diagnostics: []Diagnostic{{File: "api.go", Line: 5, Tag: "@Param", Message: "格式错误", Severity: SeverityError}},
+	}
+	require.NoError(t, cache.Put(key, result))
+
+	cached, ok := cache.Get(key)
+	require.True(t, ok)
+	assert.Equal(t, result, cached)
+	assert.Equal(t, int64(1), cache.Hits())
+	assert.Equal(t, int64(1), cache.Misses())
+}
+
+func TestCacheExpiry(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir, "1.0.0", time.Minute)
+
+	key := cache.Key("api.go", "", []byte("package api"))
+	require.NoError(t, cache.Put(key, &ParseResult{}))
+
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, ok := cache.Get(key)
+	assert.False(t, ok, "过期的缓存项不应命中")
+
+	_, ok = NewCache(dir, "2.0.0", 0).Get(key)
+	assert.False(t, ok, "其他版本写入的缓存项不应命中")
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, 1, stats.Expired)
+	assert.Positive(t, stats.Size)
+
+	removed, err := cache.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	stats, err = cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Entries)
+}
+
+func TestCacheClear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache := NewCache(dir, "1.0.0", 0)
+
+	stats, err := cache.Stats()
+	require.NoError(t, err, "缓存目录不存在时不应报错")
+	assert.Equal(t, 0, stats.Entries)
+
+	require.NoError(t, cache.Put(cache.Key("api.go", "", nil), &ParseResult{}))
+	require.NoError(t, cache.Clear())
+	assert.NoDirExists(t, dir)
+}
+
+func TestCacheKeepsForeignFiles(t *testing.T) {
+	// 缓存目录指向项目目录时，只处理缓存写入的文件
+	dir := t.TempDir()
+	cache := NewCache(dir, "1.0.0", 0)
+
+	key := cache.Key("api.go", "", nil)
+	require.NoError(t, cache.Put(key, &ParseResult{}))
+	foreign := []string{
+		"package.json",
+		filepath.Join(".vscode", "settings.json"),
+		filepath.Join(key[:2], "notes.json"),
+		filepath.Join("ab", strings.Repeat("cd", 32)+".json"),
+	}
+	for _, name := range foreign {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+	}
+
+	stats, err := NewCache(dir, "2.0.0", 0).Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Entries)
+
+	removed, err := NewCache(dir, "2.0.0", 0).Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	require.NoError(t, cache.Put(key, &ParseResult{}))
+	require.NoError(t, cache.Clear())
+	assert.NoFileExists(t, cache.path(key))
+	for _, name := range foreign {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	assert.Equal(t, filepath.Join("api", ".swag-gen", "cache"), CacheDir("api", ""))
+	assert.Equal(t, filepath.Join("api", "tmp"), CacheDir("api", "tmp"))
+	assert.Equal(t, "/var/cache/swag-gen", CacheDir("api", "/var/cache/swag-gen"))
+}
+
+func TestParserUsesCache(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	apiFile := filepath.Join(tmpDir, "api.go")
+	require.NoError(t, os.WriteFile(apiFile, []byte("package api\n\n// @Router /users [get]\nfunc GetUsers() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "model.go"), []byte("package api\n\ntype User struct{}\n"), 0644))
+
+	parse := func() (*Cache, []*Endpoint) {
+		cache := NewCache(cacheDir, "1.0.0", time.Hour)
+		parser := NewParser(&config.Config{}, logger)
+		parser.SetCache(cache)
+
+		endpoints, err := parser.ParseProject(tmpDir)
+		require.NoError(t, err)
+		return cache, endpoints
+	}
+
+	cache, endpoints := parse()
+	assert.Equal(t, int64(0), cache.Hits())
+	assert.Equal(t, int64(2), cache.Misses())
+	require.Len(t, endpoints, 1)
+
+	cache, cached := parse()
+	assert.Equal(t, int64(2), cache.Hits())
+	assert.Equal(t, int64(0), cache.Misses())
+	assert.Equal(t, endpoints, cached)
+
+	// 修改后的文件重新解析
+	require.NoError(t, os.WriteFile(apiFile, []byte("package api\n\n// @Router /accounts [get]\nfunc GetUsers() {}\n"), 0644))
+	cache, endpoints = parse()
+	assert.Equal(t, int64(1), cache.Hits())
+	assert.Equal(t, int64(1), cache.Misses())
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "/accounts", endpoints[0].Path)
+}
+
+func TestParserCacheResolvesTypesEachRun(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "api.go"), []byte(`package api
+
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUsers() {}
+`), 0644))
+	modelFile := filepath.Join(tmpDir, "model.go")
+	require.NoError(t, os.WriteFile(modelFile, []byte("package api\n\ntype Account struct{}\n"), 0644))
+
+	parse := func() (*Parser, *Cache, []*Endpoint) {
+		cache := NewCache(cacheDir, "1.0.0", time.Hour)
+		parser := NewParser(&config.Config{}, logger)
+		parser.SetCache(cache)
+
+		endpoints, err := parser.ParseProject(tmpDir)
+		require.NoError(t, err)
+		require.Len(t, endpoints, 1)
+		return parser, cache, endpoints
+	}
+
+	parser, _, endpoints := parse()
+	assert.Equal(t, "User", endpoints[0].Responses["200"].Schema.Ref)
+	assert.Equal(t, 1, CountSeverity(parser.Diagnostics(), SeverityError))
+
+	// 类型在其他文件中补充后，缓存的端点重新解析类型引用
+	require.NoError(t, os.WriteFile(modelFile, []byte("package api\n\ntype User struct{}\n"), 0644))
+	parser, cache, endpoints := parse()
+	assert.Equal(t, int64(1), cache.Hits())
+	assert.Equal(t, "example.com/app.User", endpoints[0].Responses["200"].Schema.Ref)
+	assert.Empty(t, parser.Diagnostics())
+
+	// 全部命中缓存时使用缓存的类型信息，不加载包
+	parser, cache, endpoints = parse()
+	assert.Equal(t, int64(2), cache.Hits())
+	assert.Equal(t, "example.com/app.User", endpoints[0].Responses["200"].Schema.Ref)
+	assert.Empty(t, parser.Types().loadedPackages())
+}
+
+// BenchmarkParseProjectCache 比较没有缓存与缓存全部命中时解析项目的耗时。
+// 缓存只跳过注释解析，路由发现与生成数据模型仍会加载包
+func BenchmarkParseProjectCache(b *testing.B) {
+	tmpDir := b.TempDir()
+	require.NoError(b, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n"), 0644))
+	for i := 0; i < 200; i++ {
+		content := fmt.Sprintf(`package api
+
+// Item%[1]d 是第 %[1]d 个数据模型
+type Item%[1]d struct {
+	ID   int    `+"`json:\"id\"`"+`
+	Name string `+"`json:\"name\"`"+`
+}
+
+// GetItem%[1]d 获取数据
+// @Summary 获取数据 %[1]d
+// @Param id path int true "ID"
+// @Param q query string false "关键字"
+// @Success 200 {object} Item%[1]d
+// @Failure 404 {object} Item%[1]d
+// @Router /items%[1]d/{id} [get]
+func GetItem%[1]d() {}
+`, i)
+		// 没有注释的辅助函数，接近实际项目中文件的大小
+		for j := 0; j < 20; j++ {
+			content += fmt.Sprintf(`
+func helper%[1]d_%[2]d(items []Item%[1]d, limit int) map[int]string {
+	result := make(map[int]string, len(items))
+	for i, item := range items {
+		if i >= limit {
+			break
+		}
+		if item.Name == "" {
+			continue
+		}
+		result[item.ID] = item.Name
+	}
+	return result
+}
+`, i, j)
+		}
+		require.NoError(b, os.WriteFile(filepath.Join(tmpDir, fmt.Sprintf("item%d.go", i)), []byte(content), 0644))
+	}
+
+	parse := func(b *testing.B, cache *Cache) {
+		parser := NewParser(&config.Config{}, zap.NewNop())
+		if cache != nil {
+			parser.SetCache(cache)
+		}
+		endpoints, err := parser.ParseProject(tmpDir)
+		require.NoError(b, err)
+		require.Len(b, endpoints, 200)
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			parse(b, nil)
+		}
+	})
+
+	b.Run("warm", func(b *testing.B) {
+		cacheDir := filepath.Join(b.TempDir(), "cache")
+		parse(b, NewCache(cacheDir, "1.0.0", 0))
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			parse(b, NewCache(cacheDir, "1.0.0", 0))
+		}
+	})
+}
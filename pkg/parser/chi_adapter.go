@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+)
+
+// chiImportPaths 是 chi 各主版本的导入路径
+var chiImportPaths = []string{
+	"github.com/go-chi/chi/v5",
+	"github.com/go-chi/chi/v4",
+	"github.com/go-chi/chi",
+}
+
+// chiMethods 把 chi 路由器上的注册方法映射为 HTTP 方法
+var chiMethods = map[string]string{
+	"Get":     "GET",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Delete":  "DELETE",
+	"Patch":   "PATCH",
+	"Head":    "HEAD",
+	"Options": "OPTIONS",
+	"Connect": "CONNECT",
+	"Trace":   "TRACE",
+}
+
+// chiParamPattern 匹配 chi 的 {name} 与 {name:regexp} 路径参数，以及末尾的 * 通配符
+var chiParamPattern = regexp.MustCompile(`\{([A-Za-z0-9_]+)(?::[^}]*)?\}|/\*$`)
+
+// ChiAdapter 从 chi 的路由注册代码中提取路由，支持 Route、Group、With 与 Mount
+type ChiAdapter struct{}
+
+// NewChiAdapter 创建一个 chi 路由适配器
+func NewChiAdapter() *ChiAdapter {
+	return &ChiAdapter{}
+}
+
+// Name 返回框架名
+func (a *ChiAdapter) Name() string {
+	return "chi"
+}
+
+// IsRouterType 识别 *chi.Mux、chi.Router 与 chi.Routes
+func (a *ChiAdapter) IsRouterType(expr ast.Expr, imports map[string]string) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return isPackageSelector(expr, imports, chiImportPaths, "Mux", "Router", "Routes")
+}
+
+// IsRoot 识别 chi.NewRouter() 与 chi.NewMux()
+func (a *ChiAdapter) IsRoot(expr ast.Expr, imports map[string]string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	return isPackageSelector(call.Fun, imports, chiImportPaths, "NewRouter", "NewMux")
+}
+
+// Group 识别返回子路由器的 Route、Group 与 With 调用
+func (a *ChiAdapter) Group(call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	switch sel.Sel.Name {
+	case "Group", "With":
+		return "", true
+	case "Route":
+		if len(call.Args) > 0 {
+			return stringLit(call.Args[0])
+		}
+	}
+	return "", false
+}
+
+// Subrouters 识别 Route("/p", fn)、Group(fn) 与 Mount("/p", handler)
+func (a *ChiAdapter) Subrouters(call *ast.CallExpr) []Subrouter {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	switch sel.Sel.Name {
+	case "Route", "Mount":
+		if len(call.Args) != 2 {
+			return nil
+		}
+		prefix, ok := stringLit(call.Args[0])
+		if !ok {
+			return nil
+		}
+		return []Subrouter{{Prefix: prefix, Router: call.Args[1]}}
+	case "Group":
+		if len(call.Args) != 1 {
+			return nil
+		}
+		return []Subrouter{{Router: call.Args[0]}}
+	}
+	return nil
+}
+
+// Routes 识别 Get/Post/... 与 Method/MethodFunc 调用
+func (a *ChiAdapter) Routes(call *ast.CallExpr) []RouteCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	args := call.Args
+	method, ok := chiMethods[sel.Sel.Name]
+	if !ok {
+		if sel.Sel.Name != "Method" && sel.Sel.Name != "MethodFunc" || len(args) == 0 {
+			return nil
+		}
+		if method, ok = stringLit(args[0]); !ok {
+			return nil
+		}
+		args = args[1:]
+	}
+
+	if len(args) != 2 {
+		return nil
+	}
+
+	routePath, ok := stringLit(args[0])
+	if !ok {
+		return nil
+	}
+
+	return []RouteCall{{Method: method, Path: routePath, Handler: args[1]}}
+}
+
+// OpenAPIPath 去掉参数中的正则表达式，把末尾的 * 转换为 {wildcard}
+func (a *ChiAdapter) OpenAPIPath(path string) string {
+	return chiParamPattern.ReplaceAllStringFunc(path, func(match string) string {
+		if match == "/*" {
+			return "/{wildcard}"
+		}
+		return "{" + chiParamPattern.FindStringSubmatch(match)[1] + "}"
+	})
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChiAdapterRoutes(t *testing.T) {
+	adapter := NewChiAdapter()
+
+	routes := adapter.Routes(parseCallExpr(t, `r.Get("/users/{id}", getUser)`))
+	require.Len(t, routes, 1)
+	assert.Equal(t, "GET", routes[0].Method)
+	assert.Equal(t, "/users/{id}", routes[0].Path)
+
+	routes = adapter.Routes(parseCallExpr(t, `r.MethodFunc("PATCH", "/users/{id}", updateUser)`))
+	require.Len(t, routes, 1)
+	assert.Equal(t, "PATCH", routes[0].Method)
+
+	assert.Empty(t, adapter.Routes(parseCallExpr(t, `r.HandleFunc("/users", users)`)))
+}
+
+func TestChiAdapterGroupAndSubrouters(t *testing.T) {
+	adapter := NewChiAdapter()
+
+	prefix, ok := adapter.Group(parseCallExpr(t, `r.Route("/users", func(r chi.Router) {})`))
+	assert.True(t, ok)
+	assert.Equal(t, "/users", prefix)
+
+	prefix, ok = adapter.Group(parseCallExpr(t, `r.With(auth)`))
+	assert.True(t, ok)
+	assert.Empty(t, prefix)
+
+	subs := adapter.Subrouters(parseCallExpr(t, `r.Mount("/admin", adminRouter())`))
+	require.Len(t, subs, 1)
+	assert.Equal(t, "/admin", subs[0].Prefix)
+
+	subs = adapter.Subrouters(parseCallExpr(t, `r.Group(func(r chi.Router) {})`))
+	require.Len(t, subs, 1)
+	assert.Empty(t, subs[0].Prefix)
+}
+
+func TestChiAdapterOpenAPIPath(t *testing.T) {
+	adapter := NewChiAdapter()
+
+	assert.Equal(t, "/users/{id}", adapter.OpenAPIPath("/users/{id:[0-9]+}"))
+	assert.Equal(t, "/files/{wildcard}", adapter.OpenAPIPath("/files/*"))
+}
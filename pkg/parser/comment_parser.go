@@ -2,7 +2,10 @@ package parser
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"go.uber.org/zap"
@@ -22,8 +25,42 @@ func NewCommentParser(logger *zap.Logger) *CommentParser {
 
 // ParseEndpoint 从注释中解析端点信息
 func (cp *CommentParser) ParseEndpoint(comments []string, filePath string, line int) *Endpoint {
-	if len(comments) == 0 {
-		return nil
+	endpoint, diagnostics := cp.parseEndpoint(textLines(comments, filePath), filePath, line)
+	cp.logDiagnostics(diagnostics)
+	return endpoint
+}
+
+// ParseOperation 从注释中解析操作信息，不要求 @Router 标签，
+// 用于路由由注册代码发现、注释只补充文档的处理函数
+func (cp *CommentParser) ParseOperation(comments []string, filePath string, line int) *Endpoint {
+	endpoint, diagnostics := cp.parseOperation(textLines(comments, filePath), filePath, line)
+	cp.logDiagnostics(diagnostics)
+	return endpoint
+}
+
+// logDiagnostics 记录没有调用方收集的诊断信息
+func (cp *CommentParser) logDiagnostics(diagnostics []Diagnostic) {
+	for _, d := range diagnostics {
+		cp.logger.Warn("注释格式错误", zap.String("diagnostic", d.Error()))
+	}
+}
+
+// parseEndpoint 解析端点信息并返回诊断信息，没有 @Router 标签时端点为 nil
+func (cp *CommentParser) parseEndpoint(lines []commentLine, filePath string, line int) (*Endpoint, []Diagnostic) {
+	endpoint, diagnostics := cp.parseOperation(lines, filePath, line)
+
+	// 只返回有 @Router 标签的端点
+	if endpoint == nil || endpoint.Method == "" {
+		return nil, diagnostics
+	}
+
+	return endpoint, diagnostics
+}
+
+// parseOperation 解析操作信息，无法解析的注释行记录为诊断信息
+func (cp *CommentParser) parseOperation(lines []commentLine, filePath string, line int) (*Endpoint, []Diagnostic) {
+	if len(lines) == 0 {
+		return nil, nil
 	}
 
 	endpoint := &Endpoint{
@@ -34,80 +71,166 @@ func (cp *CommentParser) ParseEndpoint(comments []string, filePath string, line
 		Responses:  make(map[string]Response),
 	}
 
-	hasRouter := false
+	var diagnostics []Diagnostic
 
-	for _, comment := range comments {
+	// @Header 可以出现在对应的响应之前，解析完所有响应后再应用
+	var headers []responseHeader
+
+	// continued 为可以跨行的标签，其后不以 @ 开头的注释行追加到该标签的内容，空行结束续行
+	var continued string
+
+	for _, cl := range lines {
 		// 移除注释前缀
-		text := strings.TrimPrefix(comment, "//")
+		text := strings.TrimPrefix(cl.Text, "//")
 		text = strings.TrimSpace(text)
-
-		// 解析 @Router 标签
-		if router := cp.parseRouter(text); router != nil {
+		tag := tagName(text)
+
+		if tag == "" {
+			switch {
+			case text == "":
+				continued = ""
+			case continued == "@summary":
+				endpoint.Summary = joinLine(endpoint.Summary, text, " ")
+			case continued == "@description":
+				endpoint.Description = joinLine(endpoint.Description, text, "\n")
+			}
+			continue
+		}
+		continued = strings.ToLower(tag)
+
+		switch strings.ToLower(tag) {
+		case "@router":
+			router := cp.parseRouter(text)
+			if router == nil {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityError, "格式错误，应为 @Router /path [method]"))
+				continue
+			}
+			if !supportedMethod(router.Method) {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityError,
+					"不支持的 HTTP 方法 %s，应为 %s 之一", router.Method, strings.Join(httpMethods, "、")))
+				continue
+			}
 			endpoint.Method = router.Method
 			endpoint.Path = router.Path
-			hasRouter = true
+		case "@summary":
+			endpoint.Summary = cp.parseSimpleTag(text, tag)
+		case "@description":
+			// 多个 @Description 按行拼接
+			endpoint.Description = joinLine(endpoint.Description, cp.parseSimpleTag(text, tag), "\n")
+		case "@tags":
+			// 多个标签以逗号分隔，如 @Tags User, Admin
+			for _, name := range strings.Split(cp.parseSimpleTag(text, tag), ",") {
+				if name = strings.TrimSpace(name); name != "" && !slices.Contains(endpoint.Tags, name) {
+					endpoint.Tags = append(endpoint.Tags, name)
+				}
+			}
+		case "@param":
+			param, err := cp.parseParam(text)
+			if param == nil {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityError, `格式错误，应为 @Param name in type required "description"`))
+				continue
+			}
+			if err != nil {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityError, "%v", err))
+			}
+			endpoint.Parameters = append(endpoint.Parameters, *param)
+		case "@success", "@failure":
+			resp := cp.parseResponse(text, tag)
+			if resp == nil {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityError, `格式错误，应为 %s code {type} Model "description"`, tag))
+				continue
+			}
+			endpoint.Responses[resp.StatusCode] = *resp
+		case "@id":
+			endpoint.OperationID = cp.parseSimpleTag(text, tag)
+		case "@deprecated":
+			endpoint.Deprecated = true
+		case "@security":
+			endpoint.Security = append(endpoint.Security, ParseSecurity(cp.parseSimpleTag(text, tag))...)
+		case "@accept", "@produce":
+			types, unknown := cp.parseMIMETypes(text)
+			for _, item := range unknown {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityWarning, "无法识别的 MIME 类型 %q", item))
+			}
+			if strings.EqualFold(tag, "@accept") {
+				endpoint.Accept = append(endpoint.Accept, types...)
+			} else {
+				endpoint.Produce = append(endpoint.Produce, types...)
+			}
+		case "@header":
+			header := cp.parseHeader(text)
+			if header == nil {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityError, `格式错误，应为 @Header code {type} name "description"`))
+				continue
+			}
+			if _, err := headerSchema(header.Header.Type); err != nil {
+				diagnostics = append(diagnostics, newDiagnostic(cl, tag, SeverityWarning, "%v", err))
+			}
+			header.line = cl
+			headers = append(headers, *header)
 		}
+	}
 
-		// 解析 @Summary 标签
-		if summary := cp.parseSimpleTag(text, "@Summary"); summary != "" {
-			endpoint.Summary = summary
+	for _, header := range headers {
+		for _, status := range applyHeader(endpoint, header) {
+			diagnostics = append(diagnostics, newDiagnostic(header.line, "@Header", SeverityWarning,
+				"状态码 %s 没有对应的 @Success 或 @Failure，响应头 %s 被忽略", status, header.Name))
 		}
+	}
 
-		// 解析 @Description 标签
-		if desc := cp.parseSimpleTag(text, "@Description"); desc != "" {
-			endpoint.Description = desc
-		}
+	return endpoint, diagnostics
+}
 
-		// 解析 @Tags 标签
-		if tags := cp.parseSimpleTag(text, "@Tags"); tags != "" {
-			endpoint.Tags = append(endpoint.Tags, tags)
-		}
+// joinLine 用 sep 把续行追加到已有内容之后
+func joinLine(value, line, sep string) string {
+	if value == "" {
+		return line
+	}
+	return value + sep + line
+}
 
-		// 解析 @Param 标签
-		if param := cp.parseParam(text); param != nil {
-			endpoint.Parameters = append(endpoint.Parameters, *param)
-		}
+// parseMIMETypes 解析 @Accept 与 @Produce 标签，同时返回无法识别的类型
+// 格式: @Accept json,xml,mpfd
+func (cp *CommentParser) parseMIMETypes(text string) ([]string, []string) {
+	value := cp.parseSimpleTag(text, tagName(text))
 
-		// 解析 @Success 标签
-		if resp := cp.parseResponse(text, "@Success"); resp != nil {
-			endpoint.Responses[resp.StatusCode] = *resp
+	var unknown []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if _, ok := MIMEType(item); !ok {
+			unknown = append(unknown, item)
 		}
+	}
 
-		// 解析 @Failure 标签
-		if resp := cp.parseResponse(text, "@Failure"); resp != nil {
-			endpoint.Responses[resp.StatusCode] = *resp
-		}
+	return MIMETypes(value), unknown
+}
 
-		// 解析 @Deprecated 标签
-		if strings.Contains(text, "@Deprecated") {
-			endpoint.Deprecated = true
-		}
+// tagName 返回注释行开头的标签名，如 "@Param"；不以 @ 开头时返回空字符串
+func tagName(text string) string {
+	if !strings.HasPrefix(text, "@") {
+		return ""
 	}
-
-	// 只返回有 @Router 标签的端点
-	if !hasRouter {
-		return nil
+	if idx := strings.IndexAny(text, " \t"); idx != -1 {
+		return text[:idx]
 	}
+	return text
+}
 
-	return endpoint
+// httpMethods 是 OpenAPI 路径项支持的 HTTP 方法
+var httpMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "TRACE"}
+
+// supportedMethod 判断 HTTP 方法是否可以写入文档，方法应为大写
+func supportedMethod(method string) bool {
+	return slices.Contains(httpMethods, method)
 }
 
-// parseRouter 解析 @Router 标签
+// parseRouter 解析 @Router 标签，方法统一为大写
 // 格式: @Router /api/users [GET]
 func (cp *CommentParser) parseRouter(text string) *struct {
 	Method string
 	Path   string
 } {
-	if !strings.Contains(text, "@Router") {
-		return nil
-	}
-
-	// 使用正则表达式解析
-	re := regexp.MustCompile(`@Router\s+(\S+)\s+\[(\w+)\]`)
-	matches := re.FindStringSubmatch(text)
-
-	if len(matches) < 3 {
-		cp.logger.Warn("@Router 标签格式错误", zap.String("text", text))
+	matches := routerPattern.FindStringSubmatch(text)
+	if matches == nil {
 		return nil
 	}
 
@@ -115,7 +238,7 @@ func (cp *CommentParser) parseRouter(text string) *struct {
 		Method string
 		Path   string
 	}{
-		Method: matches[2],
+		Method: strings.ToUpper(matches[2]),
 		Path:   matches[1],
 	}
 }
@@ -139,53 +262,421 @@ func (cp *CommentParser) parseSimpleTag(text, tag string) string {
 	return content
 }
 
-// parseParam 解析 @Param 标签
-// 格式: @Param page query int false "Page number"
-func (cp *CommentParser) parseParam(text string) *Parameter {
-	if !strings.Contains(text, "@Param") {
+// routerPattern 匹配 @Router 标签，标签名不区分大小写
+// 格式: @Router /path [method]
+var routerPattern = regexp.MustCompile(`(?i:@Router)\s+(\S+)\s+\[(\w+)\]`)
+
+// paramPattern 匹配 @Param 标签，标签名不区分大小写，描述之后是可选的属性
+// 格式: @Param name in type required "description" Enums(asc, desc) default(asc)
+var paramPattern = regexp.MustCompile(`(?i:@Param)\s+(\S+)\s+(\w+)\s+(\S+)\s+(true|false)(?:\s+"([^"]*)")?(.*)$`)
+
+// responsePattern 匹配 @Success/@Failure 标签
+// 格式: @Success 200 {object} User "description"、@Success 204 "description"、@Success 204
+var responsePattern = regexp.MustCompile(`^@\w+\s+(\d{3}|default)(?:\s+\{(\w+)\}\s+(\S+))?(?:\s+"([^"]*)")?\s*$`)
+
+// headerPattern 匹配 @Header 标签
+// 格式: @Header 200,201 {integer} X-Total-Count "description"、@Header all {[]string} Link
+var headerPattern = regexp.MustCompile(`^@\w+\s+([^\s{]+(?:\s*,\s*[^\s{]+)*)\s+\{([^{}\s]+)\}\s+(\S+)(?:\s+"([^"]*)")?\s*$`)
+
+// responseHeader 代表一条 @Header 注释，Statuses 为 all 时作用于所有响应
+type responseHeader struct {
+	Statuses []string
+	Name     string
+	Header   Header
+
+	line commentLine // 注释所在行，用于报告没有对应响应的状态码
+}
+
+// parseHeader 解析 @Header 标签
+func (cp *CommentParser) parseHeader(text string) *responseHeader {
+	matches := headerPattern.FindStringSubmatch(text)
+	if matches == nil {
 		return nil
 	}
 
-	// 使用正则表达式解析
-	re := regexp.MustCompile(`@Param\s+(\w+)\s+(\w+)\s+(\w+)\s+(true|false)\s+"([^"]*)"`)
-	matches := re.FindStringSubmatch(text)
+	schema, _ := headerSchema(matches[2])
 
-	if len(matches) < 6 {
-		cp.logger.Debug("@Param 标签格式不完整", zap.String("text", text))
-		return nil
+	var statuses []string
+	for _, status := range strings.Split(matches[1], ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, strings.ToLower(status))
+		}
+	}
+
+	return &responseHeader{
+		Statuses: statuses,
+		Name:     matches[3],
+		Header: Header{
+			Type:        matches[2],
+			Description: matches[4],
+			Schema:      schema,
+		},
+	}
+}
+
+// headerSchema 通过 typeSchema 构造响应头的数据模型。响应头只能是基本类型或基本类型的数组，
+// 其他类型按 string 处理、缺少元素类型的 array 按 []string 处理，并返回说明原因的错误
+func headerSchema(typeName string) (*Schema, error) {
+	schema, err := typeSchema(typeName)
+	if err != nil {
+		return &Schema{Type: "string"}, fmt.Errorf("%v，按 string 处理", err)
+	}
+	if schema.Type == "array" && schema.Items == nil {
+		schema.Items = &Schema{Type: "string"}
+		return schema, fmt.Errorf("类型 %q 缺少元素类型，按 []string 处理，可写作 {[]integer} 等形式", typeName)
 	}
 
-	return &Parameter{
+	items := schema
+	if schema.Type == "array" {
+		items = schema.Items
+	}
+	switch items.Type {
+	case "string", "integer", "number", "boolean":
+		return schema, nil
+	}
+	return &Schema{Type: "string"}, fmt.Errorf("类型 %q 不能用作响应头，按 string 处理", typeName)
+}
+
+// applyHeader 把响应头添加到对应状态码的响应上，只作用于已声明的响应，
+// 返回没有对应响应的状态码
+func applyHeader(endpoint *Endpoint, header responseHeader) []string {
+	var unmatched []string
+	for _, status := range header.Statuses {
+		matched := false
+		for code, resp := range endpoint.Responses {
+			if status != "all" && status != code {
+				continue
+			}
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]Header)
+			}
+			resp.Headers[header.Name] = header.Header
+			endpoint.Responses[code] = resp
+			matched = true
+		}
+		if !matched {
+			unmatched = append(unmatched, status)
+		}
+	}
+	return unmatched
+}
+
+// parseParam 解析 @Param 标签
+// 格式: @Param page query int false "Page number" minimum(1) default(1)
+// 格式错误时返回 nil；属性无效时仍返回参数，忽略无效的属性并返回错误
+func (cp *CommentParser) parseParam(text string) (*Parameter, error) {
+	matches := paramPattern.FindStringSubmatch(text)
+	if len(matches) < 7 {
+		return nil, nil
+	}
+
+	param := &Parameter{
 		Name:        matches[1],
 		In:          matches[2],
 		Type:        matches[3],
 		Required:    matches[4] == "true",
 		Description: matches[5],
 	}
+
+	schema, err := typeSchema(matches[3])
+	if err != nil {
+		return nil, nil
+	}
+	param.Schema = schema
+
+	return param, applyParamAttributes(param, matches[6])
+}
+
+// paramAttribute 代表 @Param 末尾的一个属性，如 default(asc)
+type paramAttribute struct {
+	Name  string
+	Value string
+}
+
+// collectionFormats 是 collectionFormat() 支持的取值
+var collectionFormats = map[string]bool{"csv": true, "ssv": true, "tsv": true, "pipes": true, "multi": true}
+
+// applyParamAttributes 把属性应用到参数的数据模型上，数组参数的属性约束数组元素
+func applyParamAttributes(param *Parameter, text string) error {
+	attrs, err := splitParamAttributes(text)
+
+	target := param.Schema
+	if target.Type == "array" && target.Items != nil {
+		target = target.Items
+	}
+
+	var errs []string
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	for _, attr := range attrs {
+		value := strings.TrimSpace(attr.Value)
+
+		switch strings.ToLower(attr.Name) {
+		case "enums":
+			target.Enum = nil
+			for _, item := range strings.Split(value, ",") {
+				target.Enum = append(target.Enum, strings.TrimSpace(item))
+			}
+		case "default":
+			target.Default = value
+		case "example":
+			target.Example = value
+		case "format":
+			target.Format = value
+		case "pattern":
+			target.Pattern = value
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s(%s) 不是有效的数字", attr.Name, value))
+				continue
+			}
+			if strings.EqualFold(attr.Name, "minimum") {
+				target.Minimum = &n
+			} else {
+				target.Maximum = &n
+			}
+		case "minlength", "maxlength":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				errs = append(errs, fmt.Sprintf("%s(%s) 不是有效的长度", attr.Name, value))
+				continue
+			}
+			if strings.EqualFold(attr.Name, "minLength") {
+				target.MinLength = &n
+			} else {
+				target.MaxLength = &n
+			}
+		case "collectionformat":
+			if !collectionFormats[value] {
+				errs = append(errs, fmt.Sprintf("collectionFormat(%s) 无效，应为 csv、ssv、tsv、pipes 或 multi", value))
+				continue
+			}
+			if param.Schema.Type != "array" {
+				errs = append(errs, fmt.Sprintf("collectionFormat 只适用于数组参数，%s 的类型为 %s", param.Name, param.Type))
+				continue
+			}
+			param.CollectionFormat = value
+		default:
+			errs = append(errs, fmt.Sprintf("无法识别的属性 %s", attr.Name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
 }
 
-// parseResponse 解析响应标签
-// 格式: @Success 200 {object} User
+// splitParamAttributes 拆分 name(value) 形式的属性，括号可以嵌套，如 pattern(^(a|b)$)
+func splitParamAttributes(text string) ([]paramAttribute, error) {
+	var attrs []paramAttribute
+
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		if open <= 0 || strings.ContainsAny(rest[:open], " \t") {
+			return attrs, fmt.Errorf("无法解析的属性 %q，应为 name(value)", rest)
+		}
+
+		depth, end := 0, -1
+		for i := open; i < len(rest) && end < 0; i++ {
+			switch rest[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			return attrs, fmt.Errorf("属性 %s 缺少右括号", rest[:open])
+		}
+
+		attrs = append(attrs, paramAttribute{Name: rest[:open], Value: rest[open+1 : end]})
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+
+	return attrs, nil
+}
+
+// parseResponse 解析响应标签，类型可以覆盖字段，如 Resp{data=[]User,meta=Page}
+// 格式: @Success 200 {object} User "成功"
 func (cp *CommentParser) parseResponse(text, tag string) *Response {
 	if !strings.Contains(text, tag) {
 		return nil
 	}
 
-	// 使用正则表达式解析
-	re := regexp.MustCompile(tag + `\s+(\d+)\s+{(\w+)}\s+(\S+)`)
-	matches := re.FindStringSubmatch(text)
-
-	if len(matches) < 4 {
-		cp.logger.Debug("响应标签格式不完整", zap.String("text", text))
+	text = strings.TrimSpace(strings.TrimPrefix(text, "//"))
+	matches := responsePattern.FindStringSubmatch(text)
+	if len(matches) < 5 {
 		return nil
 	}
 
-	return &Response{
+	resp := &Response{
 		StatusCode:  matches[1],
-		Description: fmt.Sprintf("%s response", tag),
-		Schema: &Schema{
-			Type: matches[2],
-		},
+		Description: matches[4],
+	}
+
+	if matches[2] != "" {
+		schema, err := responseSchema(matches[2], matches[3])
+		if err != nil {
+			return nil
+		}
+		resp.Schema = schema
+	}
+
+	// 没有描述时使用标准状态文本，OpenAPI 要求响应必须有描述
+	if resp.Description == "" {
+		if code, err := strconv.Atoi(resp.StatusCode); err == nil {
+			resp.Description = http.StatusText(code)
+		}
+	}
+	if resp.Description == "" {
+		resp.Description = "Default response"
+	}
+
+	return resp
+}
+
+// responseSchema 根据 {kind} 与类型名构造响应的数据模型
+func responseSchema(kind, typeName string) (*Schema, error) {
+	switch kind {
+	case "array":
+		items, err := typeSchema(typeName)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case "object":
+		schema, err := typeSchema(typeName)
+		if err != nil {
+			return nil, err
+		}
+		if schema.Type == "" {
+			schema.Type = "object"
+		}
+		return schema, nil
+	default:
+		return &Schema{Type: primitiveType(kind)}, nil
+	}
+}
+
+// typeSchema 将 Go 类型名转换为数据模型：基本类型直接映射，其余记录为类型引用。
+// 类型名后可以用 {field=Type,...} 覆盖字段，字段类型同样可以是数组或带覆盖的类型，
+// 如 Resp{data=[]Page{items=[]User}}；覆盖的字段记录在引用类型的 Properties 中
+func typeSchema(typeName string) (*Schema, error) {
+	typeName = strings.TrimSpace(typeName)
+
+	if strings.HasPrefix(typeName, "[]") {
+		items, err := typeSchema(typeName[2:])
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	}
+
+	open := strings.IndexByte(typeName, '{')
+	if open < 0 {
+		if typeName == "" || strings.ContainsAny(typeName, "}=") {
+			return nil, fmt.Errorf("无效的类型 %q", typeName)
+		}
+		if t := primitiveType(typeName); t != "" {
+			return &Schema{Type: t}, nil
+		}
+		return &Schema{Ref: strings.TrimPrefix(typeName, "*")}, nil
+	}
+
+	if !strings.HasSuffix(typeName, "}") {
+		return nil, fmt.Errorf("类型 %q 的字段覆盖缺少右括号", typeName)
+	}
+
+	schema, err := typeSchema(typeName[:open])
+	if err != nil {
+		return nil, err
+	}
+	if schema.Ref == "" && schema.Type != "object" {
+		return nil, fmt.Errorf("类型 %s 不能覆盖字段", typeName[:open])
+	}
+
+	fields, err := splitTopLevel(typeName[open+1 : len(typeName)-1])
+	if err != nil {
+		return nil, fmt.Errorf("类型 %q: %w", typeName, err)
+	}
+
+	schema.Properties = make(map[string]*Schema, len(fields))
+	for _, field := range fields {
+		name, fieldType, ok := strings.Cut(field, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("字段覆盖 %q 应为 field=Type", field)
+		}
+
+		prop, err := typeSchema(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		schema.Properties[name] = prop
+	}
+
+	return schema, nil
+}
+
+// splitTopLevel 按不在括号内的逗号拆分字段覆盖，泛型参数中的逗号不拆分
+func splitTopLevel(text string) ([]string, error) {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i, r := range text {
+		switch r {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("括号不匹配")
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("括号不匹配")
+	}
+
+	return append(parts, text[start:]), nil
+}
+
+// primitiveType 将 Go 与 swag 的基本类型名映射为 OpenAPI 类型，非基本类型返回空字符串
+func primitiveType(typeName string) string {
+	switch typeName {
+	case "string":
+		return "string"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "integer":
+		return "integer"
+	case "float32", "float64", "number":
+		return "number"
+	case "bool", "boolean":
+		return "boolean"
+	case "file":
+		return "file"
+	case "array":
+		return "array"
+	case "object", "interface{}", "any":
+		return "object"
+	default:
+		return ""
 	}
 }
 
@@ -248,6 +739,7 @@ func (cp *CommentParser) ParseMultilineTag(comments []string, tagName string) []
 func (cp *CommentParser) SupportedTags() []string {
 	return []string{
 		"@Router",
+		"@ID",
 		"@Summary",
 		"@Description",
 		"@Tags",
@@ -255,5 +747,9 @@ func (cp *CommentParser) SupportedTags() []string {
 		"@Success",
 		"@Failure",
 		"@Deprecated",
+		"@Security",
+		"@Accept",
+		"@Produce",
+		"@Header",
 	}
 }
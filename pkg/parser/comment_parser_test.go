@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -41,6 +42,20 @@ func TestCommentParserParseRouter(t *testing.T) {
 			method:  "POST",
 			path:    "/api/users",
 		},
+		{
+			name:    "lower-case method",
+			text:    "// @Router /api/users/{id} [get]",
+			wantErr: false,
+			method:  "GET",
+			path:    "/api/users/{id}",
+		},
+		{
+			name:    "lower-case tag",
+			text:    "// @router /api/users [get]",
+			wantErr: false,
+			method:  "GET",
+			path:    "/api/users",
+		},
 		{
 			name:    "invalid router",
 			text:    "// @Router /api/users",
@@ -68,6 +83,40 @@ func TestCommentParserParseRouter(t *testing.T) {
 	}
 }
 
+func TestCommentParserLowerCaseTags(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint, diagnostics := cp.parseEndpoint(textLines([]string{
+		"// @router /api/users/{id} [get]",
+		`// @param id path int true "用户 ID"`,
+		"// @success 200 {object} User",
+	}, "api.go"), "api.go", 1)
+
+	assert.Empty(t, diagnostics)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "GET", endpoint.Method)
+	assert.Equal(t, "/api/users/{id}", endpoint.Path)
+	require.Len(t, endpoint.Parameters, 1)
+	assert.Equal(t, "id", endpoint.Parameters[0].Name)
+	assert.Contains(t, endpoint.Responses, "200")
+}
+
+func TestCommentParserUnsupportedMethod(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint, diagnostics := cp.parseEndpoint(textLines([]string{
+		"// @Router /api/users [connect]",
+	}, "api.go"), "api.go", 1)
+
+	assert.Nil(t, endpoint)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "@Router", diagnostics[0].Tag)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, "CONNECT")
+}
+
 func TestCommentParserParseSimpleTag(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	cp := NewCommentParser(logger)
@@ -146,6 +195,29 @@ func TestCommentParserParseParam(t *testing.T) {
 				Description: "User ID",
 			},
 		},
+		{
+			name:    "body param with model type",
+			text:    `// @Param body body model.CreateUserRequest true "用户信息"`,
+			wantErr: false,
+			param: &Parameter{
+				Name:        "body",
+				In:          "body",
+				Type:        "model.CreateUserRequest",
+				Required:    true,
+				Description: "用户信息",
+			},
+		},
+		{
+			name:    "array param without description",
+			text:    `// @Param ids query []int false`,
+			wantErr: false,
+			param: &Parameter{
+				Name:     "ids",
+				In:       "query",
+				Type:     "[]int",
+				Required: false,
+			},
+		},
 		{
 			name:    "invalid param",
 			text:    `// @Param page query`,
@@ -160,7 +232,8 @@ func TestCommentParserParseParam(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			param := cp.parseParam(tt.text)
+			param, err := cp.parseParam(tt.text)
+			assert.NoError(t, err)
 
 			if tt.wantErr {
 				assert.Nil(t, param)
@@ -176,6 +249,48 @@ func TestCommentParserParseParam(t *testing.T) {
 	}
 }
 
+func TestCommentParserParseParamAttributes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	param, err := cp.parseParam(`// @Param sort query string false "sort" Enums(asc, desc) default(asc) pattern(^(asc|desc)$) minLength(3) maxLength(4)`)
+	require.NoError(t, err)
+	require.NotNil(t, param)
+	assert.Equal(t, "sort", param.Description)
+	assert.Equal(t, []string{"asc", "desc"}, param.Schema.Enum)
+	assert.Equal(t, "asc", param.Schema.Default)
+	assert.Equal(t, "^(asc|desc)$", param.Schema.Pattern)
+	assert.Equal(t, 3, *param.Schema.MinLength)
+	assert.Equal(t, 4, *param.Schema.MaxLength)
+
+	param, err = cp.parseParam(`// @Param page query int false minimum(1) maximum(100) example(10)`)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, *param.Schema.Minimum)
+	assert.Equal(t, 100.0, *param.Schema.Maximum)
+	assert.Equal(t, "10", param.Schema.Example)
+
+	// 数组参数的属性约束数组元素
+	param, err = cp.parseParam(`// @Param ids query []int true "ids" collectionFormat(multi) minimum(1) format(int64)`)
+	require.NoError(t, err)
+	assert.Equal(t, "multi", param.CollectionFormat)
+	assert.Nil(t, param.Schema.Minimum)
+	assert.Equal(t, 1.0, *param.Schema.Items.Minimum)
+	assert.Equal(t, "int64", param.Schema.Items.Format)
+
+	// 无效的属性被忽略，参数仍然有效
+	param, err = cp.parseParam(`// @Param id path int true "id" minimum(abc) color(red) collectionFormat(csv)`)
+	require.NotNil(t, param)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "minimum(abc)")
+	assert.Contains(t, err.Error(), "color")
+	assert.Contains(t, err.Error(), "collectionFormat")
+	assert.Nil(t, param.Schema.Minimum)
+
+	param, err = cp.parseParam(`// @Param q query string false "q" default(a`)
+	require.NotNil(t, param)
+	assert.Error(t, err)
+}
+
 func TestCommentParserParseResponse(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	cp := NewCommentParser(logger)
@@ -203,7 +318,13 @@ func TestCommentParserParseResponse(t *testing.T) {
 		},
 		{
 			name:    "invalid response",
-			text:    "// @Success 200",
+			text:    "// @Success ok",
+			tag:     "@Success",
+			wantErr: true,
+		},
+		{
+			name:    "invalid response without type name",
+			text:    "// @Success 200 {object}",
 			tag:     "@Success",
 			wantErr: true,
 		},
@@ -223,6 +344,161 @@ func TestCommentParserParseResponse(t *testing.T) {
 	}
 }
 
+func TestCommentParserParseResponseForms(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	tests := []struct {
+		name        string
+		tag         string
+		text        string
+		statusCode  string
+		description string
+		schema      *Schema
+	}{
+		{
+			name:        "object with description",
+			tag:         "@Success",
+			text:        `// @Success 200 {object} User "成功"`,
+			statusCode:  "200",
+			description: "成功",
+			schema:      &Schema{Type: "object", Ref: "User"},
+		},
+		{
+			name:        "array of models",
+			tag:         "@Success",
+			text:        `// @Success 200 {array} model.User "成功"`,
+			statusCode:  "200",
+			description: "成功",
+			schema:      &Schema{Type: "array", Items: &Schema{Ref: "model.User"}},
+		},
+		{
+			name:        "primitive",
+			tag:         "@Success",
+			text:        `// @Success 200 {string} string "ok"`,
+			statusCode:  "200",
+			description: "ok",
+			schema:      &Schema{Type: "string"},
+		},
+		{
+			name:        "description only",
+			tag:         "@Success",
+			text:        `// @Success 204 "删除成功"`,
+			statusCode:  "204",
+			description: "删除成功",
+		},
+		{
+			name:        "status code only",
+			tag:         "@Success",
+			text:        `// @Success 204`,
+			statusCode:  "204",
+			description: "No Content",
+		},
+		{
+			name:        "success default status",
+			tag:         "@Success",
+			text:        `// @Success default {string} string "兜底响应"`,
+			statusCode:  "default",
+			description: "兜底响应",
+			schema:      &Schema{Type: "string"},
+		},
+		{
+			name:        "success default status without description",
+			tag:         "@Success",
+			text:        `// @Success default {object} Result`,
+			statusCode:  "default",
+			description: "Default response",
+			schema:      &Schema{Type: "object", Ref: "Result"},
+		},
+		{
+			name:        "failure default status without description",
+			tag:         "@Failure",
+			text:        `// @Failure default {object} ErrorResponse`,
+			statusCode:  "default",
+			description: "Default response",
+			schema:      &Schema{Type: "object", Ref: "ErrorResponse"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := cp.parseResponse(tt.text, tt.tag)
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			assert.Equal(t, tt.description, resp.Description)
+			assert.Equal(t, tt.schema, resp.Schema)
+		})
+	}
+}
+
+func TestCommentParserParseResponseOverrides(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	tests := []struct {
+		name   string
+		text   string
+		schema *Schema
+	}{
+		{
+			name: "multiple fields",
+			text: `// @Success 200 {object} Resp{data=[]User,meta=Page} "成功"`,
+			schema: &Schema{Type: "object", Ref: "Resp", Properties: map[string]*Schema{
+				"data": {Type: "array", Items: &Schema{Ref: "User"}},
+				"meta": {Ref: "Page"},
+			}},
+		},
+		{
+			name: "nested overrides",
+			text: `// @Success 200 {object} Resp{data=Page{items=[]User,total=int}}`,
+			schema: &Schema{Type: "object", Ref: "Resp", Properties: map[string]*Schema{
+				"data": {Ref: "Page", Properties: map[string]*Schema{
+					"items": {Type: "array", Items: &Schema{Ref: "User"}},
+					"total": {Type: "integer"},
+				}},
+			}},
+		},
+		{
+			name: "array of overridden type",
+			text: `// @Success 200 {array} Resp{data=string}`,
+			schema: &Schema{Type: "array", Items: &Schema{Ref: "Resp", Properties: map[string]*Schema{
+				"data": {Type: "string"},
+			}}},
+		},
+		{
+			name: "generic type with overrides",
+			text: `// @Success 200 {object} Pair[string,User]{data=[]model.User}`,
+			schema: &Schema{Type: "object", Ref: "Pair[string,User]", Properties: map[string]*Schema{
+				"data": {Type: "array", Items: &Schema{Ref: "model.User"}},
+			}},
+		},
+		{
+			name: "plain object",
+			text: `// @Success 200 {object} object{code=int}`,
+			schema: &Schema{Type: "object", Properties: map[string]*Schema{
+				"code": {Type: "integer"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := cp.parseResponse(tt.text, "@Success")
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.schema, resp.Schema)
+		})
+	}
+
+	for _, text := range []string{
+		`// @Success 200 {object} Resp{data=User`,
+		`// @Success 200 {object} Resp{data}`,
+		`// @Success 200 {object} Resp{data=Page{items=User}`,
+		`// @Success 200 {object} string{data=User}`,
+	} {
+		assert.Nil(t, cp.parseResponse(text, "@Success"), text)
+	}
+}
+
 func TestCommentParserParseEndpoint(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	cp := NewCommentParser(logger)
@@ -247,6 +523,69 @@ func TestCommentParserParseEndpoint(t *testing.T) {
 	assert.Equal(t, 10, endpoint.Line)
 }
 
+func TestCommentParserParseEndpointParamsAndResponses(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	comments := []string{
+		"// @Router /api/users/{id} [PUT]",
+		"// @Summary 更新用户",
+		`// @Param id path int true "用户 ID"`,
+		`// @Param body body UpdateUserRequest true "用户信息"`,
+		`// @Success 200 {object} User "更新成功"`,
+		`// @Failure 404 {object} ErrorResponse "用户不存在"`,
+	}
+
+	endpoint := cp.ParseEndpoint(comments, "test.go", 10)
+
+	require.NotNil(t, endpoint)
+	require.Len(t, endpoint.Parameters, 2)
+	assert.Equal(t, "id", endpoint.Parameters[0].Name)
+	assert.Equal(t, "body", endpoint.Parameters[1].In)
+	assert.Equal(t, "UpdateUserRequest", endpoint.Parameters[1].Type)
+	require.Len(t, endpoint.Responses, 2)
+	assert.Equal(t, "更新成功", endpoint.Responses["200"].Description)
+	assert.Equal(t, "User", endpoint.Responses["200"].Schema.Ref)
+	assert.Equal(t, "用户不存在", endpoint.Responses["404"].Description)
+}
+
+func TestCommentParserParseEndpointTags(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint := cp.ParseEndpoint([]string{
+		"// @Router /api/users [GET]",
+		"// @Tags User, Admin,",
+		"// @Tags Admin,Audit",
+	}, "test.go", 1)
+
+	require.NotNil(t, endpoint)
+	assert.Equal(t, []string{"User", "Admin", "Audit"}, endpoint.Tags)
+}
+
+func TestCommentParserParseEndpointContinuation(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint := cp.ParseEndpoint([]string{
+		"// GetUser 获取用户",
+		"// @Summary Get a user",
+		"// by id",
+		"// @Description Returns the user with the given id.",
+		"// Deleted users are not returned.",
+		"// @Description Requires the user:read scope.",
+		"//",
+		"// 处理函数的其他说明",
+		"// @Router /api/users/{id} [GET]",
+		"/*\n * @Tags User\n */",
+	}, "test.go", 1)
+
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "Get a user by id", endpoint.Summary)
+	assert.Equal(t, "Returns the user with the given id.\nDeleted users are not returned.\nRequires the user:read scope.", endpoint.Description)
+	assert.Equal(t, []string{"User"}, endpoint.Tags)
+}
+
 func TestCommentParserParseEndpointNoRouter(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	cp := NewCommentParser(logger)
@@ -287,8 +626,9 @@ func TestCommentParserSupportedTags(t *testing.T) {
 
 	tags := cp.SupportedTags()
 
-	assert.Len(t, tags, 8)
+	assert.Len(t, tags, 13)
 	assert.Contains(t, tags, "@Router")
+	assert.Contains(t, tags, "@ID")
 	assert.Contains(t, tags, "@Summary")
 	assert.Contains(t, tags, "@Description")
 	assert.Contains(t, tags, "@Tags")
@@ -331,3 +671,51 @@ func TestCommentParserValidateTag(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentParserParseHeaders(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint, diagnostics := cp.parseEndpoint(textLines([]string{
+		"// @Router /users [GET]",
+		`// @Header 200 {integer} X-Total-Count "总行数"`,
+		"// @Success 200 {array} User",
+		"// @Success 206 {array} User",
+		"// @Failure 400 {object} ErrorResponse",
+		`// @Header 200, 206 {string} Link "分页链接"`,
+		`// @Header all {string} X-Request-Id "请求标识"`,
+		`// @Header 500 {string} X-Ignored "没有对应的响应"`,
+		`// @Header 200 {object} X-Object`,
+		`// @Header 200 {[]int} X-Ids`,
+		`// @Header 200 {array} X-Tags`,
+	}, "test.go"), "test.go", 1)
+	require.NotNil(t, endpoint)
+
+	ok := endpoint.Responses["200"].Headers
+	require.Len(t, ok, 6)
+	assert.Equal(t, Header{Type: "integer", Description: "总行数", Schema: &Schema{Type: "integer"}}, ok["X-Total-Count"])
+	assert.Equal(t, "分页链接", ok["Link"].Description)
+	assert.Equal(t, &Schema{Type: "string"}, ok["X-Object"].Schema)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "integer"}}, ok["X-Ids"].Schema)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, ok["X-Tags"].Schema)
+
+	partial := endpoint.Responses["206"].Headers
+	assert.Len(t, partial, 2)
+	assert.Contains(t, partial, "Link")
+	assert.Contains(t, partial, "X-Request-Id")
+
+	failure := endpoint.Responses["400"].Headers
+	assert.Len(t, failure, 1)
+	assert.Contains(t, failure, "X-Request-Id")
+
+	assert.NotContains(t, endpoint.Responses, "500")
+
+	require.Len(t, diagnostics, 3)
+	assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, `"object"`)
+	assert.Equal(t, SeverityWarning, diagnostics[1].Severity)
+	assert.Contains(t, diagnostics[1].Message, `"array"`)
+	assert.Equal(t, SeverityWarning, diagnostics[2].Severity)
+	assert.Contains(t, diagnostics[2].Message, "500")
+	assert.Contains(t, diagnostics[2].Message, "X-Ignored")
+}
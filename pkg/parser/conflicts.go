@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoutePolicy 决定方法与路径相同的端点如何处理
+type RoutePolicy string
+
+const (
+	// RoutePolicyError 把冲突报告为错误，保留先出现的端点
+	RoutePolicyError RoutePolicy = "error"
+	// RoutePolicyFirstWins 保留先出现的端点，冲突报告为警告
+	RoutePolicyFirstWins RoutePolicy = "first-wins"
+	// RoutePolicyLastWins 保留后出现的端点，冲突报告为警告
+	RoutePolicyLastWins RoutePolicy = "last-wins"
+)
+
+// RoutePolicies 返回支持的冲突处理策略
+func RoutePolicies() []RoutePolicy {
+	return []RoutePolicy{RoutePolicyError, RoutePolicyFirstWins, RoutePolicyLastWins}
+}
+
+// ParseRoutePolicy 解析冲突处理策略，为空时使用 RoutePolicyError
+func ParseRoutePolicy(name string) (RoutePolicy, error) {
+	if name == "" {
+		return RoutePolicyError, nil
+	}
+	for _, policy := range RoutePolicies() {
+		if string(policy) == name {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("无效的路由冲突策略 %q，应为 error、first-wins 或 last-wins", name)
+}
+
+// RouteConflict 代表两个方法相同、路径相同或仅路径参数名不同的端点，First 先出现
+type RouteConflict struct {
+	First  *Endpoint
+	Second *Endpoint
+}
+
+// ParamNamesOnly 判断两个端点的路径是否仅参数名不同，如 /users/{id} 与 /users/{uid}
+func (c RouteConflict) ParamNamesOnly() bool {
+	return c.First.Path != c.Second.Path
+}
+
+// Diagnostic 返回冲突的诊断信息，位置为后出现的端点，消息中包含先出现的端点的位置
+func (c RouteConflict) Diagnostic(severity Severity) Diagnostic {
+	message := fmt.Sprintf("%s %s 与 %s 的路由重复", c.Second.Method, c.Second.Path, endpointLocation(c.First))
+	if c.ParamNamesOnly() {
+		message = fmt.Sprintf("%s %s 与 %s 的 %s 冲突，路径仅参数名不同",
+			c.Second.Method, c.Second.Path, endpointLocation(c.First), c.First.Path)
+	}
+
+	return Diagnostic{
+		File:     c.Second.File,
+		Line:     c.Second.Line,
+		Tag:      "@Router",
+		Message:  message,
+		Severity: severity,
+	}
+}
+
+// ResolveRouteConflicts 按策略处理方法与路径冲突的端点，端点按出现顺序排列。
+// 返回保留的端点、冲突以及诊断信息；方法不同但路径仅参数名不同的端点都会保留，
+// 路径改写为先出现的路径并报告为警告，OpenAPI 中同一路径只能有一组参数名
+func ResolveRouteConflicts(endpoints []*Endpoint, policy RoutePolicy) ([]*Endpoint, []RouteConflict, []Diagnostic) {
+	severity := SeverityWarning
+	if policy == RoutePolicyError {
+		severity = SeverityError
+	}
+
+	var (
+		kept        = make([]*Endpoint, 0, len(endpoints))
+		conflicts   []RouteConflict
+		diagnostics []Diagnostic
+		operations  = make(map[string]int)       // 方法与路径模板对应的保留端点下标
+		paths       = make(map[string]*Endpoint) // 路径模板对应的第一个端点
+		reported    = make(map[string]bool)      // 已报告参数名不同的路径
+	)
+
+	for _, endpoint := range endpoints {
+		template := pathTemplate(endpoint.Path)
+		key := strings.ToUpper(endpoint.Method) + " " + template
+
+		if i, ok := operations[key]; ok {
+			conflict := RouteConflict{First: kept[i], Second: endpoint}
+			conflicts = append(conflicts, conflict)
+			diagnostics = append(diagnostics, conflict.Diagnostic(severity))
+			if policy == RoutePolicyLastWins {
+				kept[i] = renamePathParams(endpoint, paths[template].Path)
+			}
+			continue
+		}
+
+		if first, ok := paths[template]; !ok {
+			paths[template] = endpoint
+		} else if first.Path != endpoint.Path {
+			if !reported[endpoint.Path] {
+				reported[endpoint.Path] = true
+				diagnostics = append(diagnostics, Diagnostic{
+					File:     endpoint.File,
+					Line:     endpoint.Line,
+					Tag:      "@Router",
+					Message:  fmt.Sprintf("路径 %s 与 %s 的 %s 仅参数名不同，OpenAPI 视为同一路径，已改用其参数名", endpoint.Path, endpointLocation(first), first.Path),
+					Severity: SeverityWarning,
+				})
+			}
+			endpoint = renamePathParams(endpoint, first.Path)
+		}
+
+		operations[key] = len(kept)
+		kept = append(kept, endpoint)
+	}
+
+	return kept, conflicts, diagnostics
+}
+
+// renamePathParams 返回路径改写为 path 的端点副本，path 与端点路径仅参数名不同，
+// 路径参数按出现位置改名。路径相同时返回端点本身
+func renamePathParams(endpoint *Endpoint, path string) *Endpoint {
+	if endpoint.Path == path {
+		return endpoint
+	}
+
+	names := make(map[string]string)
+	to := pathParamPattern.FindAllStringSubmatch(path, -1)
+	for i, match := range pathParamPattern.FindAllStringSubmatch(endpoint.Path, -1) {
+		names[match[1]] = to[i][1]
+	}
+
+	renamed := *endpoint
+	renamed.Path = path
+	renamed.Parameters = make([]Parameter, len(endpoint.Parameters))
+	for i, param := range endpoint.Parameters {
+		if name, ok := names[param.Name]; ok && param.In == "path" {
+			param.Name = name
+		}
+		renamed.Parameters[i] = param
+	}
+	return &renamed
+}
+
+// pathTemplate 去掉路径参数名，如 /users/{id} 变为 /users/{}
+func pathTemplate(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+// endpointLocation 返回端点的源码位置，如 api/user.go:12
+func endpointLocation(endpoint *Endpoint) string {
+	if endpoint.Line > 0 {
+		return fmt.Sprintf("%s:%d", endpoint.File, endpoint.Line)
+	}
+	return endpoint.File
+}
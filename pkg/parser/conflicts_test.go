@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseRoutePolicy(t *testing.T) {
+	policy, err := ParseRoutePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, RoutePolicyError, policy)
+
+	policy, err = ParseRoutePolicy("last-wins")
+	require.NoError(t, err)
+	assert.Equal(t, RoutePolicyLastWins, policy)
+
+	_, err = ParseRoutePolicy("random")
+	assert.Error(t, err)
+}
+
+func TestResolveRouteConflicts(t *testing.T) {
+	first := &Endpoint{Method: "GET", Path: "/users/{id}", File: "a.go", Line: 3}
+	second := &Endpoint{Method: "GET", Path: "/users/{id}", File: "b.go", Line: 7}
+	renamed := &Endpoint{Method: "GET", Path: "/users/{uid}", File: "c.go", Line: 9,
+		Parameters: []Parameter{{Name: "uid", In: "path"}}}
+	remove := &Endpoint{Method: "DELETE", Path: "/users/{uid}", File: "c.go", Line: 20,
+		Parameters: []Parameter{{Name: "uid", In: "path"}, {Name: "uid", In: "query"}}}
+	list := &Endpoint{Method: "GET", Path: "/users", File: "a.go", Line: 12}
+	endpoints := []*Endpoint{first, list, second, renamed, remove}
+
+	// 路径仅参数名不同的端点改写为先出现的路径
+	removeRenamed := &Endpoint{Method: "DELETE", Path: "/users/{id}", File: "c.go", Line: 20,
+		Parameters: []Parameter{{Name: "id", In: "path"}, {Name: "uid", In: "query"}}}
+	renamedRenamed := &Endpoint{Method: "GET", Path: "/users/{id}", File: "c.go", Line: 9,
+		Parameters: []Parameter{{Name: "id", In: "path"}}}
+
+	tests := []struct {
+		name     string
+		policy   RoutePolicy
+		kept     []*Endpoint
+		severity Severity
+	}{
+		{name: "error", policy: RoutePolicyError, kept: []*Endpoint{first, list, removeRenamed}, severity: SeverityError},
+		{name: "first wins", policy: RoutePolicyFirstWins, kept: []*Endpoint{first, list, removeRenamed}, severity: SeverityWarning},
+		{name: "last wins", policy: RoutePolicyLastWins, kept: []*Endpoint{renamedRenamed, list, removeRenamed}, severity: SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, conflicts, diagnostics := ResolveRouteConflicts(endpoints, tt.policy)
+
+			assert.Equal(t, tt.kept, kept)
+			require.Len(t, conflicts, 2)
+			assert.False(t, conflicts[0].ParamNamesOnly())
+			assert.Same(t, second, conflicts[0].Second)
+			assert.True(t, conflicts[1].ParamNamesOnly())
+			assert.Same(t, renamed, conflicts[1].Second)
+
+			require.Len(t, diagnostics, 3)
+			assert.Equal(t, "b.go", diagnostics[0].File)
+			assert.Equal(t, 7, diagnostics[0].Line)
+			assert.Equal(t, tt.severity, diagnostics[0].Severity)
+			assert.Contains(t, diagnostics[0].Message, "a.go:3")
+			assert.Equal(t, "c.go", diagnostics[1].File)
+			assert.Contains(t, diagnostics[1].Message, "仅参数名不同")
+
+			// 方法不同的端点都保留，只报告路径参数名不一致
+			assert.Equal(t, 20, diagnostics[2].Line)
+			assert.Equal(t, SeverityWarning, diagnostics[2].Severity)
+			assert.Contains(t, diagnostics[2].Message, "/users/{id}")
+
+			// 传入的端点不会被修改
+			assert.Equal(t, "/users/{uid}", remove.Path)
+			assert.Equal(t, "uid", remove.Parameters[0].Name)
+		})
+	}
+}
+
+func TestParserProjectRouteConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.go": `package api
+
+// @Router /orders [GET]
+// @Summary first
+func ListOrders() {}
+`,
+		"b.go": `package api
+
+// @Router /orders [GET]
+// @Summary second
+func ListAllOrders() {}
+`,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+
+	for _, tt := range []struct {
+		policy  string
+		summary string
+	}{
+		{policy: "", summary: "first"},
+		{policy: "first-wins", summary: "first"},
+		{policy: "last-wins", summary: "second"},
+	} {
+		p := NewParser(&config.Config{Parser: config.ParserConfig{RouteConflicts: tt.policy}}, zap.NewNop())
+		endpoints, err := p.ParseProject(tmpDir)
+		require.NoError(t, err)
+		require.Len(t, endpoints, 1)
+		assert.Equal(t, tt.summary, endpoints[0].Summary, tt.policy)
+		assert.Len(t, p.RouteConflicts(), 1)
+	}
+
+	p := NewParser(&config.Config{Parser: config.ParserConfig{RouteConflicts: "never"}}, zap.NewNop())
+	_, err := p.ParseProject(tmpDir)
+	assert.Error(t, err)
+}
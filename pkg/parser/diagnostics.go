@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Severity 代表诊断信息的严重程度
+type Severity string
+
+const (
+	// SeverityError 表示注释或源码无法解析，对应内容没有进入文档
+	SeverityError Severity = "error"
+	// SeverityWarning 表示内容已按默认方式处理，文档可能不完整
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic 代表解析过程中发现的一个问题
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int // 未知时为 0
+	Tag      string
+	Message  string
+	Severity Severity
+}
+
+// Error 以 file:line:column: severity: tag: message 的形式描述诊断信息
+func (d Diagnostic) Error() string {
+	var b strings.Builder
+
+	b.WriteString(d.File)
+	if d.Line > 0 {
+		fmt.Fprintf(&b, ":%d", d.Line)
+		if d.Column > 0 {
+			fmt.Fprintf(&b, ":%d", d.Column)
+		}
+	}
+	fmt.Fprintf(&b, ": %s: ", d.Severity)
+	if d.Tag != "" {
+		b.WriteString(d.Tag + ": ")
+	}
+	b.WriteString(d.Message)
+
+	return b.String()
+}
+
+// CountSeverity 返回指定严重程度的诊断信息数量
+func CountSeverity(diagnostics []Diagnostic, severity Severity) int {
+	count := 0
+	for _, d := range diagnostics {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// commentLine 代表一行注释及其在源文件中的位置
+type commentLine struct {
+	Text string
+	Pos  token.Position
+}
+
+// commentLines 返回注释组中每行注释的文本与位置，/* */ 块注释按行拆分
+func commentLines(fset *token.FileSet, doc *ast.CommentGroup) []commentLine {
+	lines := make([]commentLine, 0, len(doc.List))
+	for _, comment := range doc.List {
+		lines = appendCommentLines(lines, comment.Text, fset.Position(comment.Pos()))
+	}
+	return lines
+}
+
+// textLines 为没有位置信息的注释文本构造注释行，诊断信息只包含文件名
+func textLines(comments []string, filePath string) []commentLine {
+	lines := make([]commentLine, 0, len(comments))
+	for _, comment := range comments {
+		lines = appendCommentLines(lines, comment, token.Position{Filename: filePath})
+	}
+	return lines
+}
+
+// appendCommentLines 追加一条注释的注释行。行注释保持原样；块注释去掉 /* 与 */，
+// 按行拆分并去掉行首的 *，每行的位置指向该行的内容
+func appendCommentLines(lines []commentLine, text string, pos token.Position) []commentLine {
+	if !strings.HasPrefix(text, "/*") {
+		return append(lines, commentLine{Text: text, Pos: pos})
+	}
+
+	body := strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	for i, line := range strings.Split(body, "\n") {
+		linePos := pos
+		if i > 0 && pos.Line > 0 {
+			linePos.Line += i
+			linePos.Column = len(line) - len(strings.TrimLeft(line, " \t")) + 1
+		}
+
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimPrefix(line, "*"))
+		lines = append(lines, commentLine{Text: line, Pos: linePos})
+	}
+	return lines
+}
+
+// newDiagnostic 创建指向某行注释的诊断信息
+func newDiagnostic(line commentLine, tag string, severity Severity, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{
+		File:     line.Pos.Filename,
+		Line:     line.Pos.Line,
+		Column:   line.Pos.Column,
+		Tag:      tag,
+		Message:  fmt.Sprintf(format, args...),
+		Severity: severity,
+	}
+}
+
+// syntaxDiagnostics 把 Go 源码的语法错误转换为诊断信息
+func syntaxDiagnostics(filePath string, err error) []Diagnostic {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return []Diagnostic{{File: filePath, Message: err.Error(), Severity: SeverityError}}
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(list))
+	for _, e := range list {
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     e.Pos.Filename,
+			Line:     e.Pos.Line,
+			Column:   e.Pos.Column,
+			Message:  e.Msg,
+			Severity: SeverityError,
+		})
+	}
+	return diagnostics
+}
+
+// sortDiagnostics 按文件、行、列排序，使并发解析的输出稳定
+func sortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
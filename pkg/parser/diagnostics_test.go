@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDiagnosticError(t *testing.T) {
+	tests := []struct {
+		name       string
+		diagnostic Diagnostic
+		expected   string
+	}{
+		{
+			name:       "完整位置",
+			diagnostic: Diagnostic{File: "api.go", Line: 3, Column: 1, Tag: "@Param", Message: "格式错误", Severity: SeverityError},
+			expected:   "api.go:3:1: error: @Param: 格式错误",
+		},
+		{
+			name:       "没有列号",
+			diagnostic: Diagnostic{File: "api.go", Line: 3, Tag: "@Accept", Message: "无法识别", Severity: SeverityWarning},
+			expected:   "api.go:3: warning: @Accept: 无法识别",
+		},
+		{
+			name:       "只有文件名",
+			diagnostic: Diagnostic{File: "api.go", Message: "读取文件失败", Severity: SeverityError},
+			expected:   "api.go: error: 读取文件失败",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.diagnostic.Error())
+		})
+	}
+}
+
+func TestCommentParserOperationDiagnostics(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint, diagnostics := cp.parseEndpoint(textLines([]string{
+		"// @Router /api/users",
+		"// @Param id path",
+		"// @Success 200 {object}",
+		"// @Accept json,toml",
+		"// @Header 200 {object} X-Total",
+	}, "api.go"), "api.go", 1)
+
+	assert.Nil(t, endpoint)
+	require.Len(t, diagnostics, 6)
+	assert.Equal(t, "@Router", diagnostics[0].Tag)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Equal(t, "@Param", diagnostics[1].Tag)
+	assert.Equal(t, "@Success", diagnostics[2].Tag)
+	assert.Equal(t, "@Accept", diagnostics[3].Tag)
+	assert.Equal(t, SeverityWarning, diagnostics[3].Severity)
+	assert.Contains(t, diagnostics[3].Message, "toml")
+	assert.Equal(t, "@Header", diagnostics[4].Tag)
+	assert.Equal(t, SeverityWarning, diagnostics[4].Severity)
+	// @Success 格式错误，200 没有对应的响应
+	assert.Equal(t, "@Header", diagnostics[5].Tag)
+	assert.Equal(t, SeverityWarning, diagnostics[5].Severity)
+	assert.Contains(t, diagnostics[5].Message, "200")
+}
+
+func TestParserDiagnosticsDedupe(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	parser := NewParser(&config.Config{}, logger)
+
+	// 同一位置的诊断信息按并发解析的顺序记录，重复项不一定相邻
+	param := Diagnostic{File: "api.go", Line: 5, Column: 1, Tag: "@Param", Message: "缺少参数类型", Severity: SeverityError}
+	success := Diagnostic{File: "api.go", Line: 5, Column: 1, Tag: "@Success", Message: "状态码无效", Severity: SeverityWarning}
+	parser.addDiagnostics(param, success, param)
+	parser.addDiagnostics(success)
+
+	assert.Equal(t, []Diagnostic{param, success}, parser.Diagnostics())
+}
+
+func TestParserProjectDiagnostics(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	parser := NewParser(&config.Config{}, logger)
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "api.go"), []byte(`package api
+
+// GetUser 获取用户
+// @Router /users/{id} [get]
+// @Param id path int
+func GetUser() {}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "broken.go"), []byte(`package api
+
+func Broken( {
+`), 0644))
+
+	endpoints, err := parser.ParseProject(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, 6, endpoints[0].Line)
+
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 2)
+
+	assert.Equal(t, filepath.Join(tmpDir, "api.go"), diagnostics[0].File)
+	assert.Equal(t, 5, diagnostics[0].Line)
+	assert.Equal(t, 1, diagnostics[0].Column)
+	assert.Equal(t, "@Param", diagnostics[0].Tag)
+
+	assert.Equal(t, filepath.Join(tmpDir, "broken.go"), diagnostics[1].File)
+	assert.Equal(t, 3, diagnostics[1].Line)
+	assert.Equal(t, SeverityError, diagnostics[1].Severity)
+
+	assert.Equal(t, 2, CountSeverity(diagnostics, SeverityError))
+}
@@ -0,0 +1,267 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/build"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/neglet30/swag-gen/pkg/config"
+)
+
+// FileFinder 查找需要解析的 Go 文件，ParseProject 与 ASTParser.ParseDirectory 使用同一套规则。
+// 始终跳过测试文件、vendor 与 testdata 目录以及以 . 或 _ 开头的目录
+type FileFinder struct {
+	// Include 是相对于根目录的 doublestar 模式，为空时包含所有 Go 文件
+	Include []string
+	// Exclude 是要跳过的文件或目录的 doublestar 模式，不含 / 的模式匹配任意层级的名称，如 vendor
+	Exclude []string
+	// GitIgnore 为 true 时跳过 .gitignore 忽略的文件和目录
+	GitIgnore bool
+	// IncludeGenerated 为 true 时不跳过带有 "// Code generated ... DO NOT EDIT." 标记的文件
+	IncludeGenerated bool
+	// Build 用于判断文件的构建约束，包括 //go:build 注释与 _linux.go 这样的文件名后缀，
+	// 为 nil 时使用 build.Default
+	Build *build.Context
+}
+
+// NewFileFinder 根据解析器配置创建文件查找器，ExcludeDirs 与 Exclude 合并为排除模式
+func NewFileFinder(cfg config.ParserConfig) *FileFinder {
+	exclude := make([]string, 0, len(cfg.ExcludeDirs)+len(cfg.Exclude))
+	exclude = append(exclude, cfg.ExcludeDirs...)
+	exclude = append(exclude, cfg.Exclude...)
+
+	return &FileFinder{
+		Include:          cfg.Include,
+		Exclude:          exclude,
+		GitIgnore:        cfg.GitIgnore,
+		IncludeGenerated: cfg.IncludeGenerated,
+		Build:            BuildContext(cfg),
+	}
+}
+
+// BuildContext 根据配置的构建标签与目标平台创建构建上下文，判断构建约束的方式与 go build 相同。
+// 目标平台与当前平台不同时，除非设置了 CGO_ENABLED=1，否则与交叉编译一样禁用 cgo
+func BuildContext(cfg config.ParserConfig) *build.Context {
+	ctx := build.Default
+	ctx.BuildTags = append([]string(nil), cfg.BuildTags...)
+
+	if cfg.GOOS != "" {
+		ctx.GOOS = cfg.GOOS
+	}
+	if cfg.GOARCH != "" {
+		ctx.GOARCH = cfg.GOARCH
+	}
+	if ctx.GOOS != build.Default.GOOS || ctx.GOARCH != build.Default.GOARCH {
+		ctx.CgoEnabled = os.Getenv("CGO_ENABLED") == "1"
+	}
+
+	return &ctx
+}
+
+// Find 递归查找根目录下需要解析的 Go 文件，按路径排序返回
+func (f *FileFinder) Find(root string) ([]string, error) {
+	for _, pattern := range append(append([]string(nil), f.Include...), f.Exclude...) {
+		if !doublestar.ValidatePattern(pattern) {
+			return nil, fmt.Errorf("无效的文件模式: %q", pattern)
+		}
+	}
+
+	ctx := f.Build
+	if ctx == nil {
+		ctx = &build.Default
+	}
+
+	// 每个目录生效的 .gitignore 规则，包含上级目录的规则
+	ignores := make(map[string][]ignoreRule)
+
+	var files []string
+	err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." && (skipDir(d.Name()) || f.excluded(rel) || ignored(ignores[path.Dir(rel)], rel, true)) {
+				return filepath.SkipDir
+			}
+			if f.GitIgnore {
+				rules := append([]ignoreRule(nil), ignores[path.Dir(rel)]...)
+				ignores[rel] = append(rules, readGitIgnore(filePath, rel)...)
+			}
+			return nil
+		}
+
+		name := d.Name()
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+		if !f.included(rel) || f.excluded(rel) || ignored(ignores[path.Dir(rel)], rel, false) {
+			return nil
+		}
+		if match, err := ctx.MatchFile(filepath.Dir(filePath), name); err != nil || !match {
+			return nil
+		}
+		if !f.IncludeGenerated && isGeneratedFile(filePath) {
+			return nil
+		}
+
+		files = append(files, filePath)
+		return nil
+	})
+
+	return files, err
+}
+
+// skipDir 判断目录是否总是跳过，与 go 命令的规则一致
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// included 判断文件是否匹配包含模式
+func (f *FileFinder) included(rel string) bool {
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, pattern := range f.Include {
+		if matchPattern(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// excluded 判断文件或目录是否匹配排除模式
+func (f *FileFinder) excluded(rel string) bool {
+	for _, pattern := range f.Exclude {
+		if matchPattern(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern 匹配相对路径，不含 / 的模式匹配任意层级的名称
+func matchPattern(pattern, rel string) bool {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "./"), "/")
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	match, _ := doublestar.Match(pattern, rel)
+	return match
+}
+
+// ignoreRule 代表 .gitignore 中的一条规则
+type ignoreRule struct {
+	base    string // .gitignore 所在目录，相对于根目录
+	pattern string
+	negate  bool
+	dirOnly bool
+}
+
+// readGitIgnore 读取目录中的 .gitignore，文件不存在时返回 nil
+func readGitIgnore(dir, rel string) []ignoreRule {
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return nil
+	}
+
+	base := rel
+	if base == "." {
+		base = ""
+	}
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+
+		// 不含 / 的规则匹配任意层级，否则相对于 .gitignore 所在目录
+		if strings.Contains(line, "/") {
+			line = strings.TrimPrefix(line, "/")
+		} else {
+			line = "**/" + line
+		}
+		rule.pattern = line
+
+		if doublestar.ValidatePattern(rule.pattern) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// ignored 判断路径是否被 .gitignore 忽略，后面的规则优先
+func ignored(rules []ignoreRule, rel string, isDir bool) bool {
+	result := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		target := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			target = strings.TrimPrefix(rel, rule.base+"/")
+		}
+
+		if match, _ := doublestar.Match(rule.pattern, target); match {
+			result = !rule.negate
+		}
+	}
+	return result
+}
+
+// generatedPattern 匹配 Go 约定的生成文件标记
+var generatedPattern = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGeneratedFile 判断文件是否为生成文件，标记必须出现在 package 语句之前
+func isGeneratedFile(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if generatedPattern.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeTree 在目录中创建文件，键为以 / 分隔的相对路径
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// relPaths 把查找结果转换为以 / 分隔的相对路径
+func relPaths(t *testing.T, root string, files []string) []string {
+	t.Helper()
+	rels := make([]string, 0, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		require.NoError(t, err)
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+	return rels
+}
+
+func TestFileFinderDefaults(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"main.go":                "package main",
+		"api/user.go":            "package api",
+		"api/user_test.go":       "package api",
+		"api/testdata/sample.go": "package sample",
+		"api/docs.go":            "// Code generated by swaggo/swag. DO NOT EDIT.\n\npackage api",
+		"api/ignored.go":         "//go:build never\n\npackage api",
+		"api/README.md":          "# api",
+		"vendor/lib/lib.go":      "package lib",
+		".cache/tmp.go":          "package tmp",
+		"_old/old.go":            "package old",
+		"test/helper.go":         "package test",
+	})
+
+	files, err := (&FileFinder{}).Find(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/user.go", "main.go", "test/helper.go"}, relPaths(t, root, files))
+
+	files, err = (&FileFinder{IncludeGenerated: true}).Find(root)
+	require.NoError(t, err)
+	assert.Contains(t, relPaths(t, root, files), "api/docs.go")
+}
+
+func TestFileFinderIncludeExclude(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"main.go":                 "package main",
+		"api/v1/user.go":          "package v1",
+		"api/v1/user_mock.go":     "package v1",
+		"api/v2/order.go":         "package v2",
+		"internal/store/store.go": "package store",
+		"tests/e2e.go":            "package tests",
+	})
+
+	finder := NewFileFinder(config.ParserConfig{
+		Include:     []string{"api/**/*.go", "main.go"},
+		Exclude:     []string{"*_mock.go", "api/v2"},
+		ExcludeDirs: []string{"tests"},
+	})
+
+	files, err := finder.Find(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/v1/user.go", "main.go"}, relPaths(t, root, files))
+
+	_, err = (&FileFinder{Exclude: []string{"api/[v1"}}).Find(root)
+	assert.Error(t, err)
+}
+
+func TestFileFinderGitIgnore(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".gitignore":        "# 本地文件\nlocal/\n*_gen.go\n/build\n",
+		"api/user.go":       "package api",
+		"api/user_gen.go":   "package api",
+		"api/.gitignore":    "draft.go\n!keep_gen.go\n",
+		"api/draft.go":      "package api",
+		"api/keep_gen.go":   "package api",
+		"local/debug.go":    "package local",
+		"build/out.go":      "package build",
+		"sub/build/keep.go": "package build",
+	})
+
+	files, err := (&FileFinder{}).Find(root)
+	require.NoError(t, err)
+	assert.Len(t, files, 7, "未启用 gitignore 时不应跳过被忽略的文件")
+
+	files, err = (&FileFinder{GitIgnore: true}).Find(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/keep_gen.go", "api/user.go", "sub/build/keep.go"}, relPaths(t, root, files))
+}
+
+func TestFileFinderBuildConstraints(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"user.go":            "//go:build !enterprise\n\npackage api",
+		"user_enterprise.go": "//go:build enterprise\n\npackage api",
+		"path_linux.go":      "package api",
+		"path_windows.go":    "package api",
+		"path_arm64.go":      "package api",
+	})
+
+	find := func(cfg config.ParserConfig) []string {
+		files, err := NewFileFinder(cfg).Find(root)
+		require.NoError(t, err)
+		return relPaths(t, root, files)
+	}
+
+	assert.Equal(t, []string{"path_linux.go", "user.go"}, find(config.ParserConfig{GOOS: "linux", GOARCH: "amd64"}))
+	assert.Equal(t, []string{"path_linux.go", "user_enterprise.go"}, find(config.ParserConfig{GOOS: "linux", GOARCH: "amd64", BuildTags: []string{"enterprise"}}))
+	assert.Equal(t, []string{"path_arm64.go", "path_windows.go", "user.go"}, find(config.ParserConfig{GOOS: "windows", GOARCH: "arm64"}))
+}
+
+func TestBuildContext(t *testing.T) {
+	t.Setenv("CGO_ENABLED", "")
+
+	ctx := BuildContext(config.ParserConfig{BuildTags: []string{"enterprise"}})
+	assert.Equal(t, build.Default.GOOS, ctx.GOOS)
+	assert.Equal(t, build.Default.GOARCH, ctx.GOARCH)
+	assert.Equal(t, []string{"enterprise"}, ctx.BuildTags)
+	assert.Equal(t, build.Default.CgoEnabled, ctx.CgoEnabled)
+
+	goos := "windows"
+	if build.Default.GOOS == goos {
+		goos = "linux"
+	}
+	ctx = BuildContext(config.ParserConfig{GOOS: goos})
+	assert.Equal(t, goos, ctx.GOOS)
+	assert.False(t, ctx.CgoEnabled, "交叉编译时默认禁用 cgo")
+}
+
+func TestParserBuildFlavor(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"go.mod": "module example.com/shop\n",
+		"api/user.go": `//go:build !enterprise
+
+package api
+
+// @Router /users [get]
+// @Summary 社区版
+// @Success 200 {object} model.User
+func ListUsers() {}
+`,
+		"api/user_enterprise.go": `//go:build enterprise
+
+package api
+
+// @Router /users [get]
+// @Summary 企业版
+// @Success 200 {object} model.User
+func ListUsers() {}
+`,
+		"model/user.go":            "package model\n\ntype User struct{ ID int }\n",
+		"model/user_enterprise.go": "//go:build enterprise\n\npackage model\n\ntype Tenant struct{ ID int }\n",
+	})
+
+	parse := func(tags ...string) ([]*Endpoint, *TypeRegistry) {
+		cfg := &config.Config{Parser: config.ParserConfig{BuildTags: tags}}
+		parser := NewParser(cfg, zap.NewNop())
+		endpoints, err := parser.ParseProject(root)
+		require.NoError(t, err)
+		return endpoints, parser.Types()
+	}
+
+	endpoints, types := parse()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "社区版", endpoints[0].Summary)
+	assert.Nil(t, types.Lookup("example.com/shop/model.Tenant"))
+
+	endpoints, types = parse("enterprise")
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "企业版", endpoints[0].Summary)
+	assert.NotNil(t, types.Lookup("example.com/shop/model.Tenant"))
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+)
+
+// echoImportPaths 是 echo 各主版本的导入路径
+var echoImportPaths = []string{
+	"github.com/labstack/echo/v4",
+	"github.com/labstack/echo",
+}
+
+// echoMethods 是 echo 路由器上按 HTTP 方法命名的注册方法
+var echoMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"PATCH":   true,
+	"HEAD":    true,
+	"OPTIONS": true,
+	"CONNECT": true,
+	"TRACE":   true,
+}
+
+// echoParamPattern 匹配 echo 的 :name 路径参数与 * 通配符
+var echoParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)|\*`)
+
+// EchoAdapter 从 echo 的 Echo 与 Group 注册代码中提取路由
+type EchoAdapter struct{}
+
+// NewEchoAdapter 创建一个 echo 路由适配器
+func NewEchoAdapter() *EchoAdapter {
+	return &EchoAdapter{}
+}
+
+// Name 返回框架名
+func (a *EchoAdapter) Name() string {
+	return "echo"
+}
+
+// IsRouterType 识别 *echo.Echo 与 *echo.Group
+func (a *EchoAdapter) IsRouterType(expr ast.Expr, imports map[string]string) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return isPackageSelector(expr, imports, echoImportPaths, "Echo", "Group")
+}
+
+// IsRoot 识别 echo.New()
+func (a *EchoAdapter) IsRoot(expr ast.Expr, imports map[string]string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	return isPackageSelector(call.Fun, imports, echoImportPaths, "New")
+}
+
+// Group 识别 Group("/prefix", ...) 调用
+func (a *EchoAdapter) Group(call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
+		return "", false
+	}
+	return stringLit(call.Args[0])
+}
+
+// Subrouters echo 的子路由器都通过 Group 返回，没有挂载调用
+func (a *EchoAdapter) Subrouters(call *ast.CallExpr) []Subrouter {
+	return nil
+}
+
+// Routes 识别 GET/POST/...、Add 与 Match 调用。echo 的处理函数紧跟路径，其后是中间件
+func (a *EchoAdapter) Routes(call *ast.CallExpr) []RouteCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	args := call.Args
+	var methods []string
+
+	switch name := sel.Sel.Name; {
+	case echoMethods[name]:
+		methods = []string{name}
+	case name == "Add" && len(args) > 0:
+		method, ok := stringLit(args[0])
+		if !ok {
+			return nil
+		}
+		methods, args = []string{method}, args[1:]
+	case name == "Match" && len(args) > 0:
+		methods, args = stringSliceLit(args[0]), args[1:]
+	default:
+		return nil
+	}
+
+	if len(args) < 2 {
+		return nil
+	}
+
+	routePath, ok := stringLit(args[0])
+	if !ok {
+		return nil
+	}
+
+	routes := make([]RouteCall, 0, len(methods))
+	for _, method := range methods {
+		routes = append(routes, RouteCall{Method: method, Path: routePath, Handler: args[1]})
+	}
+	return routes
+}
+
+// OpenAPIPath 把 :id 转换为 {id}，把 * 转换为 {wildcard}
+func (a *EchoAdapter) OpenAPIPath(path string) string {
+	return echoParamPattern.ReplaceAllStringFunc(path, func(match string) string {
+		if match == "*" {
+			return "{wildcard}"
+		}
+		return "{" + match[1:] + "}"
+	})
+}
+
+// stringSliceLit 返回 []string{"a", "b"} 字面量中的字符串，忽略非字面量元素
+func stringSliceLit(expr ast.Expr) []string {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, elt := range lit.Elts {
+		if value, ok := stringLit(elt); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
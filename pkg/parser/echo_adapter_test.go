@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEchoAdapterRoutes(t *testing.T) {
+	adapter := NewEchoAdapter()
+
+	// 处理函数紧跟路径，其后是中间件
+	routes := adapter.Routes(parseCallExpr(t, `e.GET("/users/:id", getUser, auth)`))
+	require.Len(t, routes, 1)
+	assert.Equal(t, "GET", routes[0].Method)
+	assert.Equal(t, "getUser", routes[0].Handler.(*ast.Ident).Name)
+
+	routes = adapter.Routes(parseCallExpr(t, `e.Add("PUT", "/users/:id", updateUser)`))
+	require.Len(t, routes, 1)
+	assert.Equal(t, "PUT", routes[0].Method)
+
+	routes = adapter.Routes(parseCallExpr(t, `e.Match([]string{"GET", "HEAD"}, "/ping", ping)`))
+	require.Len(t, routes, 2)
+	assert.Equal(t, "HEAD", routes[1].Method)
+
+	assert.Empty(t, adapter.Routes(parseCallExpr(t, `e.Any("/users", users)`)))
+}
+
+func TestEchoAdapterOpenAPIPath(t *testing.T) {
+	adapter := NewEchoAdapter()
+
+	assert.Equal(t, "/users/{id}", adapter.OpenAPIPath("/users/:id"))
+	assert.Equal(t, "/static/{wildcard}", adapter.OpenAPIPath("/static/*"))
+}
@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strings"
+)
+
+// EnumValue 代表类型化常量声明中的一个枚举值
+type EnumValue struct {
+	Name    string
+	Value   interface{} // string、int64、float64 或 bool
+	Comment string
+}
+
+// collectEnums 收集包中以本包命名类型声明的常量，作为该类型的枚举值。
+// 支持显式类型（StatusPending OrderStatus = "pending"）、类型转换（OrderStatus("pending")）
+// 以及 iota 块中省略类型与表达式的隐式重复
+func (r *TypeRegistry) collectEnums(pkg *Package) {
+	consts := make(map[string]constant.Value)
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+
+			var typeExpr ast.Expr
+			var values []ast.Expr
+			for index, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				// 省略类型与表达式时沿用上一行
+				if valueSpec.Type != nil || len(valueSpec.Values) > 0 {
+					typeExpr, values = valueSpec.Type, valueSpec.Values
+				}
+
+				for i, name := range valueSpec.Names {
+					if i >= len(values) {
+						break
+					}
+
+					value := evalConst(values[i], index, consts)
+					if value == nil {
+						continue
+					}
+					consts[name.Name] = value
+
+					typeName := enumTypeName(typeExpr, values[i])
+					typeDecl, ok := pkg.Types[typeName]
+					if !ok || name.Name == "_" {
+						continue
+					}
+
+					enumValue := constValue(value)
+					if enumValue == nil {
+						continue
+					}
+
+					typeDecl.Enums = append(typeDecl.Enums, EnumValue{
+						Name:    name.Name,
+						Value:   enumValue,
+						Comment: constComment(valueSpec),
+					})
+				}
+			}
+		}
+	}
+}
+
+// enumTypeName 返回常量的声明类型名：优先取显式类型，其次取类型转换表达式中的类型
+func enumTypeName(typeExpr, value ast.Expr) string {
+	if ident, ok := typeExpr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	if call, ok := value.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if ident, ok := call.Fun.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+
+	return ""
+}
+
+// evalConst 计算常量表达式，支持字面量、iota、同包常量、一元与二元运算以及类型转换；
+// 无法计算时返回 nil
+func evalConst(expr ast.Expr, iota int, consts map[string]constant.Value) constant.Value {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		value := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		if value.Kind() == constant.Unknown {
+			return nil
+		}
+		return value
+	case *ast.Ident:
+		switch e.Name {
+		case "iota":
+			return constant.MakeInt64(int64(iota))
+		case "true":
+			return constant.MakeBool(true)
+		case "false":
+			return constant.MakeBool(false)
+		}
+		return consts[e.Name]
+	case *ast.ParenExpr:
+		return evalConst(e.X, iota, consts)
+	case *ast.UnaryExpr:
+		x := evalConst(e.X, iota, consts)
+		if x == nil {
+			return nil
+		}
+		return constant.UnaryOp(e.Op, x, 0)
+	case *ast.BinaryExpr:
+		x := evalConst(e.X, iota, consts)
+		y := evalConst(e.Y, iota, consts)
+		if x == nil || y == nil || !compatibleKinds(x, y) {
+			return nil
+		}
+		switch e.Op {
+		case token.SHL, token.SHR:
+			shift, ok := constant.Uint64Val(y)
+			if !ok || x.Kind() != constant.Int {
+				return nil
+			}
+			return constant.Shift(x, e.Op, uint(shift))
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return constant.MakeBool(constant.Compare(x, e.Op, y))
+		case token.QUO:
+			if constant.Sign(y) == 0 {
+				return nil
+			}
+			if x.Kind() == constant.Int && y.Kind() == constant.Int {
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y)
+			}
+		}
+		return constant.BinaryOp(x, e.Op, y)
+	case *ast.CallExpr:
+		// 类型转换，如 OrderStatus("pending")
+		if len(e.Args) == 1 {
+			return evalConst(e.Args[0], iota, consts)
+		}
+	}
+
+	return nil
+}
+
+// compatibleKinds 判断两个常量能否参与同一个二元运算：字符串与布尔值只能与同类值运算
+func compatibleKinds(x, y constant.Value) bool {
+	for _, kind := range []constant.Kind{constant.String, constant.Bool} {
+		if (x.Kind() == kind) != (y.Kind() == kind) {
+			return false
+		}
+	}
+	return true
+}
+
+// constValue 把常量值转换为可序列化的 Go 值
+func constValue(value constant.Value) interface{} {
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value)
+	case constant.Bool:
+		return constant.BoolVal(value)
+	case constant.Int:
+		if v, ok := constant.Int64Val(value); ok {
+			return v
+		}
+	case constant.Float:
+		v, _ := constant.Float64Val(value)
+		return v
+	}
+	return nil
+}
+
+// constComment 返回常量的文档注释或行尾注释
+func constComment(spec *ast.ValueSpec) string {
+	if spec.Doc != nil {
+		return strings.TrimSpace(spec.Doc.Text())
+	}
+	if spec.Comment != nil {
+		return strings.TrimSpace(spec.Comment.Text())
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTypeRegistryCollectEnums(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/order.go": `package model
+
+type OrderStatus string
+
+type Priority int
+
+type Flag uint
+
+type Ratio float64
+
+type Order struct {
+	Status OrderStatus
+}
+`,
+		"model/consts.go": `package model
+
+const (
+	// StatusPending 等待支付
+	StatusPending OrderStatus = "pending"
+	StatusPaid    OrderStatus = "paid" // 已支付
+	StatusClosed              = OrderStatus("closed")
+	unrelated                 = "x"
+)
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityMedium
+	_
+	PriorityHigh
+)
+
+const (
+	FlagRead Flag = 1 << iota
+	FlagWrite
+	FlagExec
+)
+
+const RatioHalf Ratio = 1.0 / 2
+
+const base = 10
+
+const PriorityUrgent Priority = base * 2
+`,
+	})
+
+	types := NewTypeRegistry(logger)
+	require.NoError(t, types.LoadModule(root))
+	require.NotNil(t, types.PackageByDir(filepath.Join(root, "model")))
+
+	status := types.Lookup("example.com/shop/model.OrderStatus")
+	require.NotNil(t, status)
+	assert.Equal(t, []EnumValue{
+		{Name: "StatusPending", Value: "pending", Comment: "StatusPending 等待支付"},
+		{Name: "StatusPaid", Value: "paid", Comment: "已支付"},
+		{Name: "StatusClosed", Value: "closed"},
+	}, status.Enums)
+
+	priority := types.Lookup("example.com/shop/model.Priority")
+	require.NotNil(t, priority)
+	assert.Equal(t, []EnumValue{
+		{Name: "PriorityLow", Value: int64(1)},
+		{Name: "PriorityMedium", Value: int64(2)},
+		{Name: "PriorityHigh", Value: int64(4)},
+		{Name: "PriorityUrgent", Value: int64(20)},
+	}, priority.Enums)
+
+	flags := types.Lookup("example.com/shop/model.Flag")
+	require.NotNil(t, flags)
+	var values []interface{}
+	for _, enum := range flags.Enums {
+		values = append(values, enum.Value)
+	}
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(4)}, values)
+
+	ratio := types.Lookup("example.com/shop/model.Ratio")
+	require.NotNil(t, ratio)
+	require.Len(t, ratio.Enums, 1)
+	assert.Equal(t, 0.5, ratio.Enums[0].Value)
+
+	assert.Empty(t, types.Lookup("example.com/shop/model.Order").Enums)
+}
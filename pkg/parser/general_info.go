@@ -0,0 +1,257 @@
+package parser
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// generalInfoTags 是只出现在通用信息注释中的标签，用于识别通用信息注释块
+var generalInfoTags = map[string]bool{
+	"@title":                    true,
+	"@version":                  true,
+	"@termsofservice":           true,
+	"@contact.name":             true,
+	"@contact.url":              true,
+	"@contact.email":            true,
+	"@license.name":             true,
+	"@license.url":              true,
+	"@host":                     true,
+	"@basepath":                 true,
+	"@schemes":                  true,
+	"@server":                   true,
+	"@externaldocs.description": true,
+	"@externaldocs.url":         true,
+	"@tag.name":                 true,
+}
+
+// generalInfoPattern 快速判断文件中是否可能包含通用信息注释
+var generalInfoPattern = regexp.MustCompile(`(?m)^\s*//\s*@(?i:title|version|host|basepath|contact\.|license\.|securitydefinitions\.|tag\.name)`)
+
+// ParseGeneralInfo 从注释中解析 API 通用信息，没有通用信息标签时返回 nil
+func (cp *CommentParser) ParseGeneralInfo(comments []string) *GeneralInfo {
+	info, diagnostics := cp.parseGeneralInfo(textLines(comments, ""))
+	cp.logDiagnostics(diagnostics)
+	return info
+}
+
+// parseGeneralInfo 解析 API 通用信息并返回诊断信息，没有通用信息标签时返回 nil
+func (cp *CommentParser) parseGeneralInfo(lines []commentLine) (*GeneralInfo, []Diagnostic) {
+	info := &GeneralInfo{}
+	found := false
+	var diagnostics []Diagnostic
+
+	// 当前正在定义的安全方案，其后的 @in、@name、@scope.* 等标签属于该方案。
+	// 类型不支持的方案仍然接收这些标签，但不写入文档
+	var scheme *SecurityScheme
+	dropped := false
+	flush := func() {
+		if scheme != nil && !dropped {
+			info.SecuritySchemes = append(info.SecuritySchemes, *scheme)
+		}
+	}
+	// 当前正在定义的标签在 info.Tags 中的下标，其后的 @tag.* 标签属于该标签
+	current := -1
+
+	for _, line := range lines {
+		text := strings.TrimSpace(strings.TrimPrefix(line.Text, "//"))
+		tag := tagName(text)
+		value := strings.TrimSpace(strings.TrimPrefix(text, tag))
+
+		if strings.HasPrefix(strings.ToLower(tag), "@securitydefinitions.") {
+			flush()
+			var err error
+			scheme, err = newSecurityScheme(tag, value)
+			dropped = err != nil
+			if dropped {
+				diagnostics = append(diagnostics, newDiagnostic(line, tag, SeverityWarning, "%v，安全方案 %s 没有写入文档", err, value))
+				scheme = &SecurityScheme{Name: value}
+			}
+			found = true
+			continue
+		}
+		if scheme != nil && applySecurityAttribute(scheme, tag, value) {
+			continue
+		}
+
+		switch strings.ToLower(tag) {
+		case "@title":
+			info.Title = value
+		case "@version":
+			info.Version = value
+		case "@description":
+			// 多行描述逐行拼接
+			if info.Description != "" {
+				info.Description += "\n"
+			}
+			info.Description += value
+		case "@termsofservice":
+			info.TermsOfService = value
+		case "@contact.name":
+			info.Contact.Name = value
+		case "@contact.url":
+			info.Contact.URL = value
+		case "@contact.email":
+			info.Contact.Email = value
+		case "@license.name":
+			info.License.Name = value
+		case "@license.url":
+			info.License.URL = value
+		case "@host":
+			info.Host = value
+		case "@basepath":
+			info.BasePath = value
+		case "@schemes":
+			info.Schemes = strings.Fields(value)
+		case "@server":
+			// 格式: @server https://api.example.com 生产环境
+			url, description, _ := strings.Cut(value, " ")
+			if url != "" {
+				info.Servers = append(info.Servers, Server{
+					URL:         url,
+					Description: strings.Trim(strings.TrimSpace(description), `"`),
+				})
+			}
+		case "@externaldocs.description":
+			info.ExternalDocs.Description = value
+		case "@externaldocs.url":
+			info.ExternalDocs.URL = value
+		case "@security":
+			info.Security = append(info.Security, ParseSecurity(value)...)
+		case "@tag.name":
+			info.Tags = append(info.Tags, TagInfo{Name: value})
+			current = len(info.Tags) - 1
+		case "@tag.description", "@tag.docs.url", "@tag.docs.description", "@tag.group":
+			if current < 0 {
+				continue
+			}
+			applyTagAttribute(&info.Tags[current], strings.ToLower(tag), value)
+		default:
+			continue
+		}
+
+		if generalInfoTags[strings.ToLower(tag)] {
+			found = true
+		}
+	}
+
+	flush()
+
+	if !found {
+		return nil, diagnostics
+	}
+
+	return info, diagnostics
+}
+
+// applyTagAttribute 把 @tag.* 注释应用到标签上，多行描述逐行拼接
+func applyTagAttribute(tag *TagInfo, name, value string) {
+	switch name {
+	case "@tag.description":
+		if tag.Description != "" {
+			tag.Description += "\n"
+		}
+		tag.Description += value
+	case "@tag.docs.url":
+		tag.ExternalDocs.URL = value
+	case "@tag.docs.description":
+		tag.ExternalDocs.Description = value
+	case "@tag.group":
+		tag.Group = value
+	}
+}
+
+// isGeneralInfoBlock 判断注释块是否为通用信息注释：包含通用信息标签且不是端点注释
+func isGeneralInfoBlock(comments []string) bool {
+	general := false
+	for _, comment := range comments {
+		tag := strings.ToLower(tagName(strings.TrimSpace(strings.TrimPrefix(comment, "//"))))
+		if tag == "@router" {
+			return false
+		}
+		if generalInfoTags[tag] || strings.HasPrefix(tag, "@securitydefinitions.") {
+			general = true
+		}
+	}
+	return general
+}
+
+// GeneralInfo 返回项目解析时读取的 API 通用信息，没有时返回 nil
+func (p *Parser) GeneralInfo() *GeneralInfo {
+	return p.info
+}
+
+// ParseGeneralInfo 从文件的通用信息注释块中解析 API 通用信息
+func (p *Parser) ParseGeneralInfo(filePath string) (*GeneralInfo, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析通用信息失败: %w", err)
+	}
+
+	var lines []commentLine
+	for _, group := range file.Comments {
+		texts := make([]string, 0, len(group.List))
+		for _, comment := range group.List {
+			texts = append(texts, comment.Text)
+		}
+		if isGeneralInfoBlock(texts) {
+			lines = append(lines, commentLines(fset, group)...)
+		}
+	}
+
+	info, diagnostics := p.comments.parseGeneralInfo(lines)
+	p.addDiagnostics(diagnostics...)
+	return info, nil
+}
+
+// loadGeneralInfo 读取项目的 API 通用信息。配置了 general_info 时只读取该文件，
+// 否则依次查找 main.go 与其他文件，使用第一个包含通用信息注释的文件
+func (p *Parser) loadGeneralInfo(projectPath string, files []string) (*GeneralInfo, error) {
+	if p.config != nil && p.config.Parser.GeneralInfo != "" {
+		filePath := p.config.Parser.GeneralInfo
+		if _, err := os.Stat(filePath); err != nil && !filepath.IsAbs(filePath) {
+			filePath = filepath.Join(projectPath, filePath)
+		}
+
+		info, err := p.ParseGeneralInfo(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			p.logger.Warn("通用信息文件中没有通用信息注释", zap.String("file", filePath))
+		}
+		return info, nil
+	}
+
+	candidates := append([]string(nil), files...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return filepath.Base(candidates[i]) == "main.go" && filepath.Base(candidates[j]) != "main.go"
+	})
+
+	for _, filePath := range candidates {
+		content, err := os.ReadFile(filePath)
+		if err != nil || !generalInfoPattern.Match(content) {
+			continue
+		}
+
+		info, err := p.ParseGeneralInfo(filePath)
+		if err != nil {
+			p.logger.Debug("解析通用信息失败", zap.String("file", filePath), zap.Error(err))
+			continue
+		}
+		if info != nil {
+			p.logger.Debug("找到通用信息", zap.String("file", filePath))
+			return info, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCommentParserParseGeneralInfo(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	comments := []string{
+		"// @title 商城 API",
+		"// @version 2.0",
+		"// @description 商城后台接口",
+		"// @description 第二行描述",
+		"// @termsOfService https://example.com/terms",
+		"// @contact.name API Support",
+		"// @contact.url https://example.com/support",
+		"// @contact.email support@example.com",
+		"// @license.name Apache 2.0",
+		"// @license.url https://www.apache.org/licenses/LICENSE-2.0.html",
+		"// @host api.example.com",
+		"// @BasePath /api/v1",
+		"// @schemes https http",
+		`// @server https://staging.example.com "预发环境"`,
+		"// @externalDocs.description OpenAPI",
+		"// @externalDocs.url https://swagger.io/resources/open-api/",
+	}
+
+	info := cp.ParseGeneralInfo(comments)
+	require.NotNil(t, info)
+	assert.Equal(t, "商城 API", info.Title)
+	assert.Equal(t, "2.0", info.Version)
+	assert.Equal(t, "商城后台接口\n第二行描述", info.Description)
+	assert.Equal(t, "https://example.com/terms", info.TermsOfService)
+	assert.Equal(t, Contact{Name: "API Support", URL: "https://example.com/support", Email: "support@example.com"}, info.Contact)
+	assert.Equal(t, License{Name: "Apache 2.0", URL: "https://www.apache.org/licenses/LICENSE-2.0.html"}, info.License)
+	assert.Equal(t, "api.example.com", info.Host)
+	assert.Equal(t, "/api/v1", info.BasePath)
+	assert.Equal(t, []string{"https", "http"}, info.Schemes)
+	assert.Equal(t, []Server{{URL: "https://staging.example.com", Description: "预发环境"}}, info.Servers)
+	assert.Equal(t, ExternalDocs{Description: "OpenAPI", URL: "https://swagger.io/resources/open-api/"}, info.ExternalDocs)
+
+	// 只有描述不构成通用信息
+	assert.Nil(t, cp.ParseGeneralInfo([]string{"// @description 普通描述"}))
+}
+
+func TestCommentParserParseGeneralInfoTags(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	info := cp.ParseGeneralInfo([]string{
+		"// @title 商城 API",
+		"// @tag.description 没有 @tag.name 时忽略",
+		"// @tag.name User",
+		"// @tag.description 用户管理",
+		"// @tag.description 包括注册与登录",
+		"// @tag.docs.url https://example.com/docs/user",
+		"// @tag.docs.description 用户文档",
+		"// @tag.group 账户",
+		"// @tag.name Order",
+	})
+	require.NotNil(t, info)
+	assert.Equal(t, []TagInfo{
+		{
+			Name:         "User",
+			Description:  "用户管理\n包括注册与登录",
+			ExternalDocs: ExternalDocs{Description: "用户文档", URL: "https://example.com/docs/user"},
+			Group:        "账户",
+		},
+		{Name: "Order"},
+	}, info.Tags)
+}
+
+func TestParserLoadsGeneralInfoFromMain(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"api/user.go": `package api
+
+// @Router /users [GET]
+// @Summary 获取用户
+// @Description 获取所有用户
+func GetUsers() {}
+`,
+		"main.go": `package main
+
+// @title 商城 API
+// @version 2.0
+// @description 商城后台接口
+// @BasePath /api
+func main() {}
+`,
+	})
+
+	parser := NewParser(&config.Config{}, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+
+	info := parser.GeneralInfo()
+	require.NotNil(t, info)
+	assert.Equal(t, "商城 API", info.Title)
+	assert.Equal(t, "商城后台接口", info.Description)
+	assert.Equal(t, "/api", info.BasePath)
+}
+
+func TestParserLoadsConfiguredGeneralInfo(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"main.go": `package main
+
+// @title 默认 API
+func main() {}
+`,
+		"cmd/server/docs.go": `// Package server 服务入口
+//
+// @title 服务 API
+// @version 1.2.0
+package server
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{GeneralInfo: filepath.Join("cmd", "server", "docs.go")}}
+	parser := NewParser(cfg, logger)
+
+	_, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	info := parser.GeneralInfo()
+	require.NotNil(t, info)
+	assert.Equal(t, "服务 API", info.Title)
+	assert.Equal(t, "1.2.0", info.Version)
+
+	// 指定的文件不存在时报错
+	cfg.Parser.GeneralInfo = "missing.go"
+	_, err = parser.ParseProject(root)
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// SplitTypeArgs 拆分泛型实例化的类型名，如 Result[model.User] 返回 Result 与 [model.User]；
+// 非泛型类型返回原类型名与 nil。切片、映射类型不视为泛型实例化
+func SplitTypeArgs(name string) (string, []string) {
+	name = strings.TrimSpace(name)
+
+	start := strings.Index(name, "[")
+	if start <= 0 || !strings.HasSuffix(name, "]") || strings.HasPrefix(name, "map[") {
+		return name, nil
+	}
+
+	var args []string
+	depth, begin := 0, start+1
+	for i := start; i < len(name); i++ {
+		switch name[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 && i != len(name)-1 {
+				// 括号在结尾之前闭合，如 Foo[]Bar，不是合法的实例化
+				return name, nil
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(name[begin:i]))
+				begin = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(name[begin:len(name)-1]))
+
+	for _, arg := range args {
+		if arg == "" {
+			return name, nil
+		}
+	}
+
+	return name[:start], args
+}
+
+// splitMapType 拆分映射类型名，如 map[string]User 返回 string 与 User
+func splitMapType(name string) (string, string, bool) {
+	if !strings.HasPrefix(name, "map[") {
+		return "", "", false
+	}
+
+	depth := 0
+	for i := len("map"); i < len(name); i++ {
+		switch name[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return name[len("map["):i], name[i+1:], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// ResolveType 在文件上下文中解析类型表达式，把其中的命名类型替换为全限定标识，
+// 如 Result[[]User] 解析为 example.com/app/api.Result[[]example.com/app/model.User]。
+// 基本类型与无法解析的类型保持原样，第二个返回值表示最外层命名类型是否解析成功
+func (r *TypeRegistry) ResolveType(name string, file *ast.File, pkgPath string) (string, bool) {
+	return r.resolveType(name, importsOf(file), pkgPath)
+}
+
+// resolveType 在导入上下文中解析类型表达式，规则与 ResolveType 相同
+func (r *TypeRegistry) resolveType(name string, imports []Import, pkgPath string) (string, bool) {
+	name = strings.TrimSpace(name)
+
+	switch {
+	case strings.HasPrefix(name, "*"):
+		return r.resolveType(name[1:], imports, pkgPath)
+	case strings.HasPrefix(name, "[]"):
+		elem, ok := r.resolveType(name[2:], imports, pkgPath)
+		return "[]" + elem, ok
+	}
+
+	if key, value, ok := splitMapType(name); ok {
+		elem, resolved := r.resolveType(value, imports, pkgPath)
+		return "map[" + key + "]" + elem, resolved
+	}
+
+	if primitiveType(name) != "" {
+		return name, false
+	}
+
+	base, args := SplitTypeArgs(name)
+	id, ok := r.resolveID(base, imports, pkgPath)
+	if !ok {
+		return name, false
+	}
+	if len(args) == 0 {
+		return id, true
+	}
+
+	resolved := make([]string, len(args))
+	for i, arg := range args {
+		resolved[i], _ = r.resolveType(arg, imports, pkgPath)
+	}
+
+	return id + "[" + strings.Join(resolved, ",") + "]", true
+}
+
+// TypeParams 返回泛型类型声明的类型参数名，非泛型类型返回 nil
+func (d *TypeDecl) TypeParams() []string {
+	if d.Spec.TypeParams == nil {
+		return nil
+	}
+
+	var names []string
+	for _, field := range d.Spec.TypeParams.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
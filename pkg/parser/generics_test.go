@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSplitTypeArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantBase string
+		wantArgs []string
+	}{
+		{name: "User", wantBase: "User"},
+		{name: "[]User", wantBase: "[]User"},
+		{name: "map[string]Page[User]", wantBase: "map[string]Page[User]"},
+		{name: "Result[User]", wantBase: "Result", wantArgs: []string{"User"}},
+		{name: "model.Page[[]model.User]", wantBase: "model.Page", wantArgs: []string{"[]model.User"}},
+		{name: "Pair[string, Result[User]]", wantBase: "Pair", wantArgs: []string{"string", "Result[User]"}},
+		{name: "Result[]", wantBase: "Result[]"},
+		{name: "Foo[]Bar", wantBase: "Foo[]Bar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, args := SplitTypeArgs(tt.name)
+			assert.Equal(t, tt.wantBase, base)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestTypeRegistryResolveType(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/user.go": `package model
+
+type User struct {
+	ID int
+}
+`,
+		"api/result.go": `package api
+
+import "example.com/shop/model"
+
+// Result 通用响应
+type Result[T any] struct {
+	Data T
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+var _ model.User
+`,
+	})
+
+	types := NewTypeRegistry(logger)
+	require.NoError(t, types.LoadModule(root))
+
+	apiPkg := types.PackageByDir(filepath.Join(root, "api"))
+	require.NotNil(t, apiPkg)
+	file := apiPkg.Files[0]
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "Result[model.User]", want: "example.com/shop/api.Result[example.com/shop/model.User]", wantOK: true},
+		{name: "*Result[[]*model.User]", want: "example.com/shop/api.Result[[]example.com/shop/model.User]", wantOK: true},
+		{name: "Pair[string, Result[model.User]]", want: "example.com/shop/api.Pair[string,example.com/shop/api.Result[example.com/shop/model.User]]", wantOK: true},
+		{name: "[]Result[int]", want: "[]example.com/shop/api.Result[int]", wantOK: true},
+		{name: "Result[Missing]", want: "example.com/shop/api.Result[Missing]", wantOK: true},
+		{name: "Missing[model.User]", want: "Missing[model.User]", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := types.ResolveType(tt.name, file, apiPkg.Path)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+
+	decl := types.Lookup("example.com/shop/api.Result[example.com/shop/model.User]")
+	require.NotNil(t, decl)
+	assert.Equal(t, []string{"T"}, decl.TypeParams())
+	assert.Equal(t, []string{"K", "V"}, types.Lookup("example.com/shop/api.Pair").TypeParams())
+	assert.Nil(t, types.Lookup("example.com/shop/model.User").TypeParams())
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+)
+
+// ginImportPaths 是 gin 的导入路径
+var ginImportPaths = []string{"github.com/gin-gonic/gin"}
+
+// ginMethods 是 gin 路由器上按 HTTP 方法命名的注册方法
+var ginMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"PATCH":   true,
+	"HEAD":    true,
+	"OPTIONS": true,
+}
+
+// ginParamPattern 匹配 gin 的 :name 与 *name 路径参数
+var ginParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// GinAdapter 从 gin 的 Engine 与 RouterGroup 注册代码中提取路由
+type GinAdapter struct{}
+
+// NewGinAdapter 创建一个 gin 路由适配器
+func NewGinAdapter() *GinAdapter {
+	return &GinAdapter{}
+}
+
+// Name 返回框架名
+func (a *GinAdapter) Name() string {
+	return "gin"
+}
+
+// IsRouterType 识别 *gin.Engine、*gin.RouterGroup、gin.IRouter 与 gin.IRoutes
+func (a *GinAdapter) IsRouterType(expr ast.Expr, imports map[string]string) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return isPackageSelector(expr, imports, ginImportPaths, "Engine", "RouterGroup", "IRouter", "IRoutes")
+}
+
+// IsRoot 识别 gin.Default() 与 gin.New()
+func (a *GinAdapter) IsRoot(expr ast.Expr, imports map[string]string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	return isPackageSelector(call.Fun, imports, ginImportPaths, "Default", "New")
+}
+
+// Group 识别 Group("/prefix", ...) 调用
+func (a *GinAdapter) Group(call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Group" || len(call.Args) == 0 {
+		return "", false
+	}
+	return stringLit(call.Args[0])
+}
+
+// Subrouters gin 的子路由器都通过 Group 返回，没有挂载调用
+func (a *GinAdapter) Subrouters(call *ast.CallExpr) []Subrouter {
+	return nil
+}
+
+// Routes 识别 GET/POST/... 与 Handle 调用，最后一个参数为处理函数
+func (a *GinAdapter) Routes(call *ast.CallExpr) []RouteCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	args := call.Args
+	method := sel.Sel.Name
+	if method == "Handle" && len(args) > 0 {
+		m, ok := stringLit(args[0])
+		if !ok {
+			return nil
+		}
+		method, args = m, args[1:]
+	} else if !ginMethods[method] {
+		return nil
+	}
+
+	if len(args) < 2 {
+		return nil
+	}
+
+	routePath, ok := stringLit(args[0])
+	if !ok {
+		return nil
+	}
+
+	return []RouteCall{{Method: method, Path: routePath, Handler: args[len(args)-1]}}
+}
+
+// OpenAPIPath 把 :id 与 *path 转换为 {id} 与 {path}
+func (a *GinAdapter) OpenAPIPath(path string) string {
+	return ginParamPattern.ReplaceAllString(path, "{$1}")
+}
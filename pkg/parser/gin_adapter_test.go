@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseCallExpr 解析单个调用表达式
+func parseCallExpr(t *testing.T, src string) *ast.CallExpr {
+	t.Helper()
+
+	expr, err := parser.ParseExpr(src)
+	require.NoError(t, err)
+
+	call, ok := expr.(*ast.CallExpr)
+	require.True(t, ok)
+	return call
+}
+
+func TestGinAdapterRoutes(t *testing.T) {
+	adapter := NewGinAdapter()
+
+	tests := []struct {
+		name     string
+		src      string
+		expected []RouteCall
+	}{
+		{"GET", `r.GET("/users", list)`, []RouteCall{{Method: "GET", Path: "/users"}}},
+		{"middleware", `r.POST("/users", auth, create)`, []RouteCall{{Method: "POST", Path: "/users"}}},
+		{"Handle", `r.Handle("PATCH", "/users/:id", update)`, []RouteCall{{Method: "PATCH", Path: "/users/:id"}}},
+		{"non-literal path", `r.GET(path, list)`, nil},
+		{"Any", `r.Any("/users", list)`, nil},
+		{"Use", `r.Use(auth)`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := adapter.Routes(parseCallExpr(t, tt.src))
+			require.Len(t, routes, len(tt.expected))
+			for i, route := range routes {
+				assert.Equal(t, tt.expected[i].Method, route.Method)
+				assert.Equal(t, tt.expected[i].Path, route.Path)
+				assert.NotNil(t, route.Handler)
+			}
+		})
+	}
+}
+
+func TestGinAdapterGroup(t *testing.T) {
+	adapter := NewGinAdapter()
+
+	prefix, ok := adapter.Group(parseCallExpr(t, `r.Group("/api/v1", auth)`))
+	assert.True(t, ok)
+	assert.Equal(t, "/api/v1", prefix)
+
+	_, ok = adapter.Group(parseCallExpr(t, `r.Group(prefix)`))
+	assert.False(t, ok)
+}
+
+func TestGinAdapterIsRoot(t *testing.T) {
+	adapter := NewGinAdapter()
+	imports := map[string]string{"gin": ginImportPaths[0], "other": "example.com/other"}
+
+	expr, err := parser.ParseExpr(`gin.Default()`)
+	require.NoError(t, err)
+	assert.True(t, adapter.IsRoot(expr, imports))
+
+	expr, err = parser.ParseExpr(`other.Default()`)
+	require.NoError(t, err)
+	assert.False(t, adapter.IsRoot(expr, imports))
+}
+
+func TestGinAdapterIsRouterType(t *testing.T) {
+	adapter := NewGinAdapter()
+	imports := map[string]string{"g": ginImportPaths[0]}
+
+	for _, src := range []string{"*g.Engine", "*g.RouterGroup", "g.IRouter", "g.IRoutes"} {
+		expr, err := parser.ParseExpr(src)
+		require.NoError(t, err)
+		assert.True(t, adapter.IsRouterType(expr, imports), src)
+	}
+
+	expr, err := parser.ParseExpr("*g.Context")
+	require.NoError(t, err)
+	assert.False(t, adapter.IsRouterType(expr, imports))
+}
+
+func TestGinAdapterOpenAPIPath(t *testing.T) {
+	adapter := NewGinAdapter()
+
+	assert.Equal(t, "/users/{id}", adapter.OpenAPIPath("/users/:id"))
+	assert.Equal(t, "/files/{path}", adapter.OpenAPIPath("/files/*path"))
+	assert.Equal(t, "/users/{id}/posts/{post_id}", adapter.OpenAPIPath("/users/:id/posts/:post_id"))
+}
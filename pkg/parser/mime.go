@@ -0,0 +1,51 @@
+package parser
+
+import "strings"
+
+// mimeTypeAliases 是 @Accept 与 @Produce 中可用的 MIME 类型简写，与 swaggo 一致
+var mimeTypeAliases = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "text/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"json-api":              "application/vnd.api+json",
+	"json-stream":           "application/x-json-stream",
+	"octet-stream":          "application/octet-stream",
+	"png":                   "image/png",
+	"jpeg":                  "image/jpeg",
+	"gif":                   "image/gif",
+	"event-stream":          "text/event-stream",
+}
+
+// MIMEType 把简写或完整的 MIME 类型转换为完整形式，无法识别时返回 false
+func MIMEType(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if mimeType, ok := mimeTypeAliases[strings.ToLower(value)]; ok {
+		return mimeType, true
+	}
+	if strings.Contains(value, "/") {
+		return value, true
+	}
+	return "", false
+}
+
+// MIMETypes 解析逗号或空格分隔的 MIME 类型列表，忽略无法识别的项与重复项
+func MIMETypes(values ...string) []string {
+	var mimeTypes []string
+	seen := make(map[string]bool)
+
+	for _, value := range values {
+		for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+			mimeType, ok := MIMEType(item)
+			if !ok || seen[mimeType] {
+				continue
+			}
+			seen[mimeType] = true
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+	}
+
+	return mimeTypes
+}
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMIMETypes(t *testing.T) {
+	assert.Equal(t, []string{"application/json", "text/xml", "multipart/form-data"}, MIMETypes("json,xml,mpfd"))
+	assert.Equal(t, []string{"application/octet-stream", "application/pdf"}, MIMETypes("octet-stream application/pdf"))
+	assert.Equal(t, []string{"application/json"}, MIMETypes("json", "JSON, application/json"))
+	assert.Empty(t, MIMETypes("unknown"))
+
+	mimeType, ok := MIMEType("x-www-form-urlencoded")
+	assert.True(t, ok)
+	assert.Equal(t, "application/x-www-form-urlencoded", mimeType)
+}
+
+func TestCommentParserParseAcceptProduce(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint := cp.ParseEndpoint([]string{
+		"// @Router /files [POST]",
+		"// @Accept json,mpfd",
+		"// @Produce octet-stream",
+		"// @Produce png, bogus",
+	}, "test.go", 1)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, []string{"application/json", "multipart/form-data"}, endpoint.Accept)
+	assert.Equal(t, []string{"application/octet-stream", "image/png"}, endpoint.Produce)
+}
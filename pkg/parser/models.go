@@ -10,8 +10,14 @@ type Endpoint struct {
 	Parameters  []Parameter
 	Responses   map[string]Response
 	Deprecated  bool
+	Security    []SecurityRequirement
+	Accept      []string // 请求体的 MIME 类型，来自 @Accept
+	Produce     []string // 响应的 MIME 类型，来自 @Produce
+	OperationID string   // 来自 @ID，没有注释时由处理函数名生成
+	Handler     string   // 处理函数名，方法带有接收者类型，如 UserHandler.GetUser；匿名函数为空
+	Package     string   // 处理函数所在的包名
 	File        string
-	Line        int
+	Line        int // 函数声明所在的行号
 }
 
 // Parameter 代表一个参数
@@ -21,6 +27,9 @@ type Parameter struct {
 	Type        string
 	Required    bool
 	Description string
+	Schema      *Schema
+	// CollectionFormat 是数组参数的序列化方式，来自 collectionFormat()，取值 csv、ssv、tsv、pipes、multi
+	CollectionFormat string
 }
 
 // Response 代表一个响应
@@ -28,19 +37,128 @@ type Response struct {
 	StatusCode  string
 	Description string
 	Schema      *Schema
+	Headers     map[string]Header
+}
+
+// Header 代表一个响应头，来自 @Header
+type Header struct {
+	Type        string
+	Description string
+	Schema      *Schema
 }
 
 // Schema 代表一个数据模型
 type Schema struct {
 	Type        string
-	Properties  map[string]*Schema
+	Properties  map[string]*Schema // Ref 不为空时是覆盖引用类型的字段，来自 Resp{data=[]User} 这样的类型
 	Items       *Schema
 	Required    []string
 	Description string
+	Ref         string // 引用的 Go 类型，解析后为全限定标识，如 github.com/org/app/model.User
+
+	// 以下约束来自 @Param 的属性，如 Enums(asc, desc)、default(asc)。
+	// Enum、Default 与 Example 保留注释中的原始文本，生成文档时按类型转换
+	Format    string
+	Enum      []string
+	Default   string
+	Example   string
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int
+	MaxLength *int
+	Pattern   string
 }
 
-// ParseResult 代表解析结果
+// ParseResult 代表单个文件的解析结果。端点中的类型引用依赖其他文件，缓存时尚未解析
 type ParseResult struct {
-	Endpoints []*Endpoint
-	Errors    []error
+	Endpoints   []*Endpoint
+	Diagnostics []Diagnostic
+	Types       *FileTypes
+}
+
+// FileTypes 代表单个文件的类型信息，随解析结果缓存，解析类型引用时不必重新加载包
+type FileTypes struct {
+	PkgPath string
+	PkgName string
+	Imports []Import
+	Types   []string // 文件中声明的类型名
+}
+
+// Import 代表文件中的一条导入
+type Import struct {
+	Name string // 导入时指定的包名，没有指定时为空
+	Path string
+}
+
+// GeneralInfo 代表 API 的通用信息，来自 main 包中的 @title 等注释
+type GeneralInfo struct {
+	Title          string
+	Version        string
+	Description    string
+	TermsOfService string
+	Contact        Contact
+	License        License
+	Host           string
+	BasePath       string
+	Schemes        []string
+	Servers        []Server
+	ExternalDocs   ExternalDocs
+	// SecuritySchemes 来自 @securityDefinitions 注释，按声明顺序排列
+	SecuritySchemes []SecurityScheme
+	// Security 是通用信息块中 @Security 声明的全局安全需求
+	Security []SecurityRequirement
+	// Tags 来自 @tag.name 等注释，按声明顺序排列
+	Tags []TagInfo
+}
+
+// TagInfo 代表通用信息中声明的标签，@tag.name 之后的 @tag.* 注释属于该标签
+type TagInfo struct {
+	Name         string
+	Description  string       // 来自 @tag.description
+	ExternalDocs ExternalDocs // 来自 @tag.docs.url 与 @tag.docs.description
+	Group        string       // 来自 @tag.group，用于生成 x-tagGroups
+}
+
+// Contact 代表 API 的联系人信息
+type Contact struct {
+	Name  string
+	URL   string
+	Email string
+}
+
+// License 代表 API 的许可证信息
+type License struct {
+	Name string
+	URL  string
+}
+
+// Server 代表提供 API 的服务器
+type Server struct {
+	URL         string
+	Description string
+}
+
+// ExternalDocs 代表外部文档
+type ExternalDocs struct {
+	Description string
+	URL         string
 }
+
+// SecurityScheme 代表一个安全方案，来自 @securityDefinitions 注释
+type SecurityScheme struct {
+	Name             string
+	Type             string // basic、bearer、apikey、oauth2
+	Description      string
+	In               string // apikey 的位置：header、query、cookie
+	ParamName        string // apikey 的参数名
+	BearerFormat     string
+	Flow             string // oauth2 授权流程：application、implicit、password、accessCode
+	AuthorizationURL string
+	TokenURL         string
+	RefreshURL       string
+	Scopes           map[string]string
+}
+
+// SecurityRequirement 代表一个安全需求，键为安全方案名，值为所需的 scope。
+// 同一需求中的方案需要同时满足
+type SecurityRequirement map[string][]string
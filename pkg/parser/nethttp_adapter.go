@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"go/ast"
+	"regexp"
+	"strings"
+)
+
+// netHTTPImportPaths 是 net/http 的导入路径
+var netHTTPImportPaths = []string{"net/http"}
+
+// netHTTPWildcardPattern 匹配 ServeMux 的 {name...} 与 {$} 通配符
+var netHTTPWildcardPattern = regexp.MustCompile(`\{([A-Za-z0-9_]*)(\.\.\.)?\}|\{\$\}`)
+
+// NetHTTPAdapter 从 net/http 的 ServeMux 注册代码中提取路由，
+// 支持 Go 1.22 的 "METHOD /path/{id}" 模式。没有方法的模式匹配所有方法，无法确定操作，予以忽略
+type NetHTTPAdapter struct{}
+
+// NewNetHTTPAdapter 创建一个 net/http 路由适配器
+func NewNetHTTPAdapter() *NetHTTPAdapter {
+	return &NetHTTPAdapter{}
+}
+
+// Name 返回框架名
+func (a *NetHTTPAdapter) Name() string {
+	return "nethttp"
+}
+
+// IsRouterType 识别 *http.ServeMux
+func (a *NetHTTPAdapter) IsRouterType(expr ast.Expr, imports map[string]string) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return isPackageSelector(expr, imports, netHTTPImportPaths, "ServeMux")
+}
+
+// IsRoot 识别 http.NewServeMux()、http.DefaultServeMux，
+// 以及代表默认 ServeMux 的 http 包本身，如 http.HandleFunc
+func (a *NetHTTPAdapter) IsRoot(expr ast.Expr, imports map[string]string) bool {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		return isPackageSelector(e.Fun, imports, netHTTPImportPaths, "NewServeMux")
+	case *ast.SelectorExpr:
+		return isPackageSelector(e, imports, netHTTPImportPaths, "DefaultServeMux")
+	case *ast.Ident:
+		return isPackageIdent(e, imports, netHTTPImportPaths)
+	}
+	return false
+}
+
+// Group ServeMux 没有路由组
+func (a *NetHTTPAdapter) Group(call *ast.CallExpr) (string, bool) {
+	return "", false
+}
+
+// Subrouters ServeMux 没有可静态识别的子路由器
+func (a *NetHTTPAdapter) Subrouters(call *ast.CallExpr) []Subrouter {
+	return nil
+}
+
+// Routes 识别 Handle 与 HandleFunc 调用
+func (a *NetHTTPAdapter) Routes(call *ast.CallExpr) []RouteCall {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "Handle" && sel.Sel.Name != "HandleFunc") || len(call.Args) != 2 {
+		return nil
+	}
+
+	pattern, ok := stringLit(call.Args[0])
+	if !ok {
+		return nil
+	}
+
+	method, rest, ok := strings.Cut(strings.TrimSpace(pattern), " ")
+	if !ok {
+		return nil
+	}
+
+	// 模式中的主机名不属于路径
+	rest = strings.TrimSpace(rest)
+	idx := strings.Index(rest, "/")
+	if idx == -1 {
+		return nil
+	}
+
+	return []RouteCall{{Method: method, Path: rest[idx:], Handler: call.Args[1]}}
+}
+
+// OpenAPIPath 把 {name...} 转换为 {name}，去掉 {$}
+func (a *NetHTTPAdapter) OpenAPIPath(path string) string {
+	return netHTTPWildcardPattern.ReplaceAllStringFunc(path, func(match string) string {
+		if match == "{$}" {
+			return ""
+		}
+		return "{" + strings.TrimSuffix(strings.Trim(match, "{}"), "...") + "}"
+	})
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"go/parser"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetHTTPAdapterRoutes(t *testing.T) {
+	adapter := NewNetHTTPAdapter()
+
+	tests := []struct {
+		name   string
+		src    string
+		method string
+		path   string
+	}{
+		{"HandleFunc", `mux.HandleFunc("GET /items/{id}", getItem)`, "GET", "/items/{id}"},
+		{"Handle", `mux.Handle("POST /items", http.HandlerFunc(createItem))`, "POST", "/items"},
+		{"host", `http.HandleFunc("DELETE example.com/items/{id}", deleteItem)`, "DELETE", "/items/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := adapter.Routes(parseCallExpr(t, tt.src))
+			require.Len(t, routes, 1)
+			assert.Equal(t, tt.method, routes[0].Method)
+			assert.Equal(t, tt.path, routes[0].Path)
+		})
+	}
+
+	// 没有方法的模式匹配所有方法，忽略
+	assert.Empty(t, adapter.Routes(parseCallExpr(t, `http.HandleFunc("/items", listItems)`)))
+}
+
+func TestNetHTTPAdapterIsRoot(t *testing.T) {
+	adapter := NewNetHTTPAdapter()
+	imports := map[string]string{"http": "net/http"}
+
+	for _, src := range []string{"http", "http.NewServeMux()", "http.DefaultServeMux"} {
+		expr, err := parser.ParseExpr(src)
+		require.NoError(t, err)
+		assert.True(t, adapter.IsRoot(expr, imports), src)
+	}
+
+	expr, err := parser.ParseExpr("mux")
+	require.NoError(t, err)
+	assert.False(t, adapter.IsRoot(expr, imports))
+}
+
+func TestNetHTTPAdapterOpenAPIPath(t *testing.T) {
+	adapter := NewNetHTTPAdapter()
+
+	assert.Equal(t, "/items/{id}", adapter.OpenAPIPath("/items/{id}"))
+	assert.Equal(t, "/files/{path}", adapter.OpenAPIPath("/files/{path...}"))
+	assert.Equal(t, "/", adapter.OpenAPIPath("/{$}"))
+}
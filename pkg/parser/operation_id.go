@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OperationIDStrategy 决定由处理函数名生成 operationId 的方式
+type OperationIDStrategy string
+
+const (
+	// OperationIDCamel 使用小驼峰的函数名，如 UserHandler.GetUser 生成 getUser
+	OperationIDCamel OperationIDStrategy = "camel"
+	// OperationIDSnake 使用蛇形的函数名，如 UserHandler.GetUser 生成 get_user
+	OperationIDSnake OperationIDStrategy = "snake"
+	// OperationIDPackage 在小驼峰的函数名前加上包名，如 user 包中的 GetUser 生成 userGetUser
+	OperationIDPackage OperationIDStrategy = "package"
+	// OperationIDNone 不生成 operationId，只使用 @ID 注释
+	OperationIDNone OperationIDStrategy = "none"
+)
+
+// OperationIDStrategies 返回支持的 operationId 生成方式
+func OperationIDStrategies() []OperationIDStrategy {
+	return []OperationIDStrategy{OperationIDCamel, OperationIDSnake, OperationIDPackage, OperationIDNone}
+}
+
+// ParseOperationIDStrategy 解析 operationId 的生成方式，为空时使用 OperationIDCamel
+func ParseOperationIDStrategy(name string) (OperationIDStrategy, error) {
+	if name == "" {
+		return OperationIDCamel, nil
+	}
+	for _, strategy := range OperationIDStrategies() {
+		if string(strategy) == name {
+			return strategy, nil
+		}
+	}
+	return "", fmt.Errorf("无效的 operationId 生成方式 %q，应为 camel、snake、package 或 none", name)
+}
+
+// AssignOperationIDs 为没有 @ID 注释的端点生成 operationId，并保证所有 operationId 唯一。
+// @ID 注释优先占用名称；重复的 operationId 追加数字后缀，并返回对应的诊断信息，
+// @ID 重复报告为错误，生成的名称重复报告为警告
+func AssignOperationIDs(endpoints []*Endpoint, strategy OperationIDStrategy) []Diagnostic {
+	var diagnostics []Diagnostic
+	owners := make(map[string]*Endpoint)
+
+	claim := func(endpoint *Endpoint, explicit bool) {
+		id := endpoint.OperationID
+		first, ok := owners[id]
+		if !ok {
+			owners[id] = endpoint
+			return
+		}
+
+		unique := id
+		for n := 2; owners[unique] != nil; n++ {
+			unique = id + strconv.Itoa(n)
+		}
+		owners[unique] = endpoint
+		endpoint.OperationID = unique
+
+		d := Diagnostic{
+			File:     endpoint.File,
+			Line:     endpoint.Line,
+			Tag:      "@ID",
+			Severity: SeverityError,
+			Message: fmt.Sprintf("operationId %s 与 %s 的 %s %s 重复，已改为 %s",
+				id, endpointLocation(first), first.Method, first.Path, unique),
+		}
+		if !explicit {
+			d.Severity = SeverityWarning
+			d.Message += "，可以使用 @ID 指定"
+		}
+		diagnostics = append(diagnostics, d)
+	}
+
+	var generated []*Endpoint
+	for _, endpoint := range endpoints {
+		if endpoint.OperationID != "" {
+			claim(endpoint, true)
+		} else if strategy != OperationIDNone {
+			generated = append(generated, endpoint)
+		}
+	}
+
+	for _, endpoint := range generated {
+		endpoint.OperationID = operationID(endpoint, strategy)
+		claim(endpoint, false)
+	}
+
+	return diagnostics
+}
+
+// operationID 按生成方式返回端点的 operationId。处理函数是匿名函数时，
+// 使用方法与路径生成，如 GET /users/{id} 生成 getUsersById
+func operationID(endpoint *Endpoint, strategy OperationIDStrategy) string {
+	var words []string
+	if name := endpoint.Handler; name != "" {
+		// 方法名不包含接收者类型，如 UserHandler.GetUser 只使用 GetUser
+		words = splitWords(name[strings.LastIndex(name, ".")+1:])
+	} else {
+		words = append(words, strings.ToLower(endpoint.Method))
+		for _, segment := range strings.Split(endpoint.Path, "/") {
+			if match := pathParamPattern.FindStringSubmatch(segment); match != nil {
+				words = append(words, "by")
+				segment = match[1]
+			}
+			words = append(words, splitWords(segment)...)
+		}
+	}
+
+	switch strategy {
+	case OperationIDSnake:
+		return strings.Join(words, "_")
+	case OperationIDPackage:
+		if endpoint.Package != "" {
+			words = append(splitWords(endpoint.Package), words...)
+		}
+	}
+
+	for i := 1; i < len(words); i++ {
+		r, size := utf8.DecodeRuneInString(words[i])
+		words[i] = string(unicode.ToUpper(r)) + words[i][size:]
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords 把标识符按大小写与分隔符拆分为小写单词，连续的大写字母视为一个单词，
+// 如 GetHTTPStatus 拆分为 get、http、status
+func splitWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	return words
+}
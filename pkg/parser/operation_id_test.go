@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := map[string][]string{
+		"GetUser":       {"get", "user"},
+		"getHTTPStatus": {"get", "http", "status"},
+		"ListV2Users":   {"list", "v2", "users"},
+		"user_id":       {"user", "id"},
+		"ID":            {"id"},
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, splitWords(name), name)
+	}
+}
+
+func TestOperationIDStrategies(t *testing.T) {
+	handler := &Endpoint{Method: "GET", Path: "/users/{id}", Handler: "UserHandler.GetUser", Package: "user"}
+	anonymous := &Endpoint{Method: "GET", Path: "/users/{user_id}/orders"}
+
+	tests := []struct {
+		strategy  OperationIDStrategy
+		handler   string
+		anonymous string
+	}{
+		{strategy: OperationIDCamel, handler: "getUser", anonymous: "getUsersByUserIdOrders"},
+		{strategy: OperationIDSnake, handler: "get_user", anonymous: "get_users_by_user_id_orders"},
+		{strategy: OperationIDPackage, handler: "userGetUser", anonymous: "getUsersByUserIdOrders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			assert.Equal(t, tt.handler, operationID(handler, tt.strategy))
+			assert.Equal(t, tt.anonymous, operationID(anonymous, tt.strategy))
+		})
+	}
+
+	_, err := ParseOperationIDStrategy("kebab")
+	assert.Error(t, err)
+}
+
+func TestAssignOperationIDs(t *testing.T) {
+	explicit := &Endpoint{Method: "GET", Path: "/me", OperationID: "getUser", File: "a.go", Line: 3}
+	generated := &Endpoint{Method: "GET", Path: "/users/{id}", Handler: "GetUser", File: "b.go", Line: 5}
+	other := &Endpoint{Method: "GET", Path: "/admin/users/{id}", Handler: "AdminHandler.GetUser", File: "c.go", Line: 8}
+	duplicate := &Endpoint{Method: "GET", Path: "/self", OperationID: "getUser", File: "d.go", Line: 2}
+
+	diagnostics := AssignOperationIDs([]*Endpoint{generated, explicit, other, duplicate}, OperationIDCamel)
+
+	assert.Equal(t, "getUser", explicit.OperationID)
+	assert.Equal(t, "getUser2", duplicate.OperationID)
+	assert.Equal(t, "getUser3", generated.OperationID)
+	assert.Equal(t, "getUser4", other.OperationID)
+
+	require.Len(t, diagnostics, 3)
+	assert.Equal(t, "d.go", diagnostics[0].File)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, "a.go:3")
+	assert.Equal(t, "b.go", diagnostics[1].File)
+	assert.Equal(t, SeverityWarning, diagnostics[1].Severity)
+	assert.Contains(t, diagnostics[1].Message, "@ID")
+
+	none := &Endpoint{Method: "GET", Path: "/users", Handler: "ListUsers"}
+	assert.Empty(t, AssignOperationIDs([]*Endpoint{none}, OperationIDNone))
+	assert.Empty(t, none.OperationID)
+}
+
+func TestParserProjectOperationIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "user.go"), []byte(`package user
+
+type UserHandler struct{}
+
+// @Router /users/{id} [GET]
+func (h *UserHandler) GetUser() {}
+
+// @Router /users [GET]
+// @ID listAllUsers
+func (h *UserHandler) ListUsers() {}
+`), 0644))
+
+	p := NewParser(&config.Config{Parser: config.ParserConfig{OperationID: "package"}}, zap.NewNop())
+	endpoints, err := p.ParseProject(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	assert.Equal(t, "UserHandler.GetUser", endpoints[0].Handler)
+	assert.Equal(t, "user", endpoints[0].Package)
+	assert.Equal(t, "userGetUser", endpoints[0].OperationID)
+	assert.Equal(t, "listAllUsers", endpoints[1].OperationID)
+	assert.Empty(t, p.Diagnostics())
+}
@@ -1,13 +1,15 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
-	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
 
@@ -17,23 +19,116 @@ import (
 
 // Parser 代表代码解析器
 type Parser struct {
-	config *config.Config
-	logger *zap.Logger
-	mu     sync.Mutex
+	config   *config.Config
+	logger   *zap.Logger
+	comments *CommentParser
+	types    *TypeRegistry
+	info     *GeneralInfo
+	cache    *Cache
+	finder   *FileFinder
+	mu       sync.Mutex
+	// diagnostics 收集整个解析过程中发现的问题，由 mu 保护
+	diagnostics []Diagnostic
+	// conflicts 是最近一次解析项目时发现的路由冲突
+	conflicts []RouteConflict
 }
 
 // NewParser 创建一个新的解析器
 func NewParser(cfg *config.Config, logger *zap.Logger) *Parser {
+	var parserConfig config.ParserConfig
+	if cfg != nil {
+		parserConfig = cfg.Parser
+	}
+
+	finder := NewFileFinder(parserConfig)
+	types := NewTypeRegistry(logger)
+	types.SetBuildContext(finder.Build)
+
 	return &Parser{
-		config: cfg,
-		logger: logger,
+		config:   cfg,
+		logger:   logger,
+		comments: NewCommentParser(logger),
+		types:    types,
+		finder:   finder,
+	}
+}
+
+// Types 返回解析过程中使用的类型注册表，供构建器解析类型引用
+func (p *Parser) Types() *TypeRegistry {
+	return p.types
+}
+
+// SetCache 设置解析结果的缓存，为 nil 时每次都重新解析所有文件。
+// 缓存命中的文件不再解析 AST 和注释，端点的类型引用使用缓存的类型信息解析；
+// 路由发现与生成数据模型仍会按需加载包
+func (p *Parser) SetCache(cache *Cache) {
+	p.cache = cache
+}
+
+// Diagnostics 返回解析过程中收集的诊断信息，按文件与位置排序
+func (p *Parser) Diagnostics() []Diagnostic {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	diagnostics := append([]Diagnostic(nil), p.diagnostics...)
+	sortDiagnostics(diagnostics)
+
+	// 同一处理函数的注释可能在路由发现时再次解析，去掉重复的诊断信息。
+	// 同一位置的诊断信息不一定相邻，按文件、行、标签与消息去重
+	type key struct {
+		file    string
+		line    int
+		tag     string
+		message string
 	}
+	seen := make(map[key]bool, len(diagnostics))
+	unique := diagnostics[:0]
+	for _, d := range diagnostics {
+		k := key{d.File, d.Line, d.Tag, d.Message}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, d)
+	}
+	return unique
+}
+
+// RouteConflicts 返回最近一次解析项目时发现的路由冲突，按出现顺序排列
+func (p *Parser) RouteConflicts() []RouteConflict {
+	return append([]RouteConflict(nil), p.conflicts...)
 }
 
-// ParseProject 解析整个项目
+// addDiagnostics 记录诊断信息
+func (p *Parser) addDiagnostics(diagnostics ...Diagnostic) {
+	if len(diagnostics) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.diagnostics = append(p.diagnostics, diagnostics...)
+}
+
+// ParseProject 解析整个项目，单个文件的错误记录为诊断信息，不会中断解析
 func (p *Parser) ParseProject(projectPath string) ([]*Endpoint, error) {
+	return p.ParseProjectContext(context.Background(), projectPath)
+}
+
+// ParseProjectContext 解析整个项目，ctx 取消或超时时停止解析并返回 ctx 的错误。
+// 端点按文件路径的顺序返回，与并发调度无关
+func (p *Parser) ParseProjectContext(ctx context.Context, projectPath string) ([]*Endpoint, error) {
 	p.logger.Info("开始解析项目", zap.String("path", projectPath))
 
+	policy, err := p.routePolicy()
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := p.operationIDStrategy()
+	if err != nil {
+		return nil, err
+	}
+
 	// 验证路径
 	if _, err := os.Stat(projectPath); err != nil {
 		p.logger.Error("项目路径不存在", zap.String("path", projectPath), zap.Error(err))
@@ -49,44 +144,182 @@ func (p *Parser) ParseProject(projectPath string) ([]*Endpoint, error) {
 
 	p.logger.Info("找到 Go 文件", zap.Int("count", len(files)))
 
+	// 定位模块，用于解析跨包的类型引用
+	if err := p.types.LoadModule(projectPath); err != nil {
+		p.logger.Warn("定位 Go 模块失败", zap.String("path", projectPath), zap.Error(err))
+	}
+
+	// 读取 API 通用信息
+	info, err := p.loadGeneralInfo(projectPath, files)
+	if err != nil {
+		p.logger.Error("读取通用信息失败", zap.Error(err))
+		return nil, fmt.Errorf("读取通用信息失败: %w", err)
+	}
+	p.info = info
+
 	// 并发解析文件
+	results, err := p.parseFiles(ctx, files)
+	if err != nil {
+		p.logger.Warn("解析项目已取消", zap.Error(err))
+		return nil, fmt.Errorf("解析项目已取消: %w", err)
+	}
+
+	// 先登记所有文件声明的类型，再解析端点中的类型引用
+	for idx, result := range results {
+		if result.Types != nil {
+			p.types.AddFileTypes(files[idx], result.Types)
+		}
+	}
+
+	// 按文件顺序收集结果
 	endpoints := make([]*Endpoint, 0)
-	var wg sync.WaitGroup
-	resultChan := make(chan []*Endpoint, len(files))
-	errChan := make(chan error, len(files))
+	for _, result := range results {
+		p.resolveResult(result)
+		endpoints = append(endpoints, result.Endpoints...)
+		p.addDiagnostics(result.Diagnostics...)
+	}
 
-	for _, file := range files {
+	// 从路由注册代码中补充没有 @Router 注释的路由
+	if p.config != nil && p.config.Parser.DiscoverRoutes {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("解析项目已取消: %w", err)
+		}
+		endpoints = append(endpoints, annotatedRoutes(p.discoverRoutes(files, p.routeAdapters()), endpoints)...)
+	}
+
+	// 按策略处理方法与路径相同的端点，避免后添加的操作覆盖先添加的
+	endpoints, conflicts, diagnostics := ResolveRouteConflicts(endpoints, policy)
+	p.conflicts = conflicts
+	p.addDiagnostics(diagnostics...)
+
+	// 生成 operationId，并检查 operationId 是否唯一
+	p.addDiagnostics(AssignOperationIDs(endpoints, strategy)...)
+
+	if p.cache != nil {
+		p.logger.Info("解析缓存", zap.Int64("hits", p.cache.Hits()), zap.Int64("misses", p.cache.Misses()))
+	}
+	p.logger.Info("项目解析完成", zap.Int("endpoints", len(endpoints)), zap.Int("diagnostics", len(p.Diagnostics())))
+	return endpoints, nil
+}
+
+// routePolicy 返回配置的路由冲突策略，未配置时使用 RoutePolicyError
+func (p *Parser) routePolicy() (RoutePolicy, error) {
+	if p.config == nil {
+		return RoutePolicyError, nil
+	}
+	return ParseRoutePolicy(p.config.Parser.RouteConflicts)
+}
+
+// operationIDStrategy 返回配置的 operationId 生成方式，未配置时使用 OperationIDCamel
+func (p *Parser) operationIDStrategy() (OperationIDStrategy, error) {
+	if p.config == nil {
+		return OperationIDCamel, nil
+	}
+	return ParseOperationIDStrategy(p.config.Parser.OperationID)
+}
+
+// funcName 返回函数名，方法带有接收者类型，如 UserHandler.GetUser
+func funcName(decl *ast.FuncDecl) string {
+	if recv := recvTypeName(decl); recv != "" {
+		return recv + "." + decl.Name.Name
+	}
+	return decl.Name.Name
+}
+
+// recvTypeName 返回方法的接收者类型名，如 *UserHandler 返回 UserHandler；函数返回空字符串
+func recvTypeName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return ""
+	}
+
+	recv := decl.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return t.Name
+		}
+		return ""
+	}
+}
+
+// maxConcurrent 返回同时解析的文件数，未配置时使用 CPU 核数
+func (p *Parser) maxConcurrent() int {
+	if p.config != nil && p.config.Parser.MaxConcurrent > 0 {
+		return p.config.Parser.MaxConcurrent
+	}
+	return runtime.NumCPU()
+}
+
+// parseFiles 使用固定数量的 worker 解析文件，结果与 files 一一对应。
+// ctx 取消后不再分发新的文件，等待正在解析的文件完成后返回 ctx 的错误
+func (p *Parser) parseFiles(ctx context.Context, files []string) ([]*ParseResult, error) {
+	results := make([]*ParseResult, len(files))
+	jobs := make(chan int)
+
+	workers := p.maxConcurrent()
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
 		wg.Add(1)
-		go func(filePath string) {
+		go func() {
 			defer wg.Done()
-			eps, err := p.ParseFile(filePath)
-			if err != nil {
-				p.logger.Warn("解析文件失败", zap.String("file", filePath), zap.Error(err))
-				errChan <- err
-				return
+			for idx := range jobs {
+				result, err := p.parseFile(files[idx])
+				if err != nil {
+					result = &ParseResult{Diagnostics: syntaxDiagnostics(files[idx], err)}
+				}
+				results[idx] = result
 			}
-			if len(eps) > 0 {
-				resultChan <- eps
-			}
-		}(file)
+		}()
 	}
 
-	// 等待所有 goroutine 完成
+dispatch:
+	for idx := range files {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- idx:
+		}
+	}
+	close(jobs)
 	wg.Wait()
-	close(resultChan)
-	close(errChan)
 
-	// 收集结果
-	for eps := range resultChan {
-		endpoints = append(endpoints, eps...)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-
-	p.logger.Info("项目解析完成", zap.Int("endpoints", len(endpoints)))
-	return endpoints, nil
+	return results, nil
 }
 
-// ParseFile 解析单个文件
+// ParseFile 解析单个文件，注释中的格式问题记录为诊断信息
 func (p *Parser) ParseFile(filePath string) ([]*Endpoint, error) {
+	result, err := p.parseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Types != nil {
+		p.types.AddFileTypes(filePath, result.Types)
+	}
+	p.resolveResult(result)
+	p.addDiagnostics(result.Diagnostics...)
+	return result.Endpoints, nil
+}
+
+// parseFile 解析单个文件，文件无法读取或有语法错误时返回错误。
+// 返回的端点中类型引用尚未解析，由 resolveResult 解析
+func (p *Parser) parseFile(filePath string) (*ParseResult, error) {
 	p.logger.Debug("解析文件", zap.String("file", filePath))
 
 	// 读取文件
@@ -95,54 +328,82 @@ func (p *Parser) ParseFile(filePath string) ([]*Endpoint, error) {
 		return nil, fmt.Errorf("读取文件失败: %w", err)
 	}
 
+	// 内容没有变化的文件直接使用缓存的解析结果
+	pkgPath := p.types.ImportPath(filepath.Dir(filePath))
+	var cacheKey string
+	if p.cache != nil {
+		cacheKey = p.cache.Key(filePath, pkgPath, content)
+		if result, ok := p.cache.Get(cacheKey); ok {
+			p.logger.Debug("使用缓存的解析结果", zap.String("file", filePath))
+			return result, nil
+		}
+	}
+
 	// 创建 FileSet
 	fset := token.NewFileSet()
 
 	// 解析 AST
 	astFile, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
 	if err != nil {
-		p.logger.Warn("解析 AST 失败", zap.String("file", filePath), zap.Error(err))
+		p.logger.Debug("解析 AST 失败", zap.String("file", filePath), zap.Error(err))
 		return nil, fmt.Errorf("解析 AST 失败: %w", err)
 	}
 
 	// 提取 API 信息
-	endpoints := p.extractEndpoints(astFile, filePath)
-	p.logger.Debug("文件解析完成", zap.String("file", filePath), zap.Int("endpoints", len(endpoints)))
+	result := p.extractEndpoints(fset, astFile, filePath)
+	result.Types = fileTypes(astFile, pkgPath)
+	p.logger.Debug("文件解析完成", zap.String("file", filePath), zap.Int("endpoints", len(result.Endpoints)))
 
-	return endpoints, nil
+	if p.cache != nil {
+		if err := p.cache.Put(cacheKey, result); err != nil {
+			p.logger.Debug("写入缓存失败", zap.String("file", filePath), zap.Error(err))
+		}
+	}
+
+	return result, nil
 }
 
-// findGoFiles 查找所有 Go 文件
-func (p *Parser) findGoFiles(projectPath string) ([]string, error) {
-	var files []string
+// resolveResult 解析文件结果中端点引用的类型，诊断信息追加到结果中。
+// 类型引用依赖其他文件，每次运行都重新解析，不写入缓存
+func (p *Parser) resolveResult(result *ParseResult) {
+	if result.Types == nil {
+		return
+	}
 
-	err := filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+	for _, endpoint := range result.Endpoints {
+		result.Diagnostics = append(result.Diagnostics, p.resolveTypes(endpoint, result.Types.Imports, result.Types.PkgPath)...)
+	}
+}
 
-		// 跳过目录和非 Go 文件
-		if info.IsDir() {
-			// 跳过 vendor 和 test 目录
-			if info.Name() == "vendor" || info.Name() == "test" {
-				return filepath.SkipDir
-			}
-			return nil
-		}
+// fileTypes 收集文件的包、导入和类型声明
+func fileTypes(file *ast.File, pkgPath string) *FileTypes {
+	types := &FileTypes{
+		PkgPath: pkgPath,
+		PkgName: file.Name.Name,
+		Imports: importsOf(file),
+	}
 
-		if filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
-			files = append(files, path)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
 		}
+		for _, spec := range genDecl.Specs {
+			types.Types = append(types.Types, spec.(*ast.TypeSpec).Name.Name)
+		}
+	}
+	return types
+}
 
-		return nil
-	})
-
-	return files, err
+// findGoFiles 按配置的包含、排除规则查找需要解析的 Go 文件
+func (p *Parser) findGoFiles(projectPath string) ([]string, error) {
+	return p.finder.Find(projectPath)
 }
 
 // extractEndpoints 从 AST 中提取端点
-func (p *Parser) extractEndpoints(file *ast.File, filePath string) []*Endpoint {
-	var endpoints []*Endpoint
+func (p *Parser) extractEndpoints(fset *token.FileSet, file *ast.File, filePath string) *ParseResult {
+	result := &ParseResult{}
+	docs := make(map[*ast.CommentGroup]bool)
 
 	// 遍历所有声明
 	for _, decl := range file.Decls {
@@ -156,155 +417,228 @@ func (p *Parser) extractEndpoints(file *ast.File, filePath string) []*Endpoint {
 		if funcDecl.Doc == nil {
 			continue
 		}
+		docs[funcDecl.Doc] = true
 
 		// 解析注释
-		endpoint := p.parseComments(funcDecl.Doc, filePath, int(funcDecl.Pos()))
+		endpoint, diagnostics := p.parseComments(fset, funcDecl.Doc, filePath, fset.Position(funcDecl.Pos()).Line)
+		result.Diagnostics = append(result.Diagnostics, diagnostics...)
 		if endpoint != nil {
-			endpoints = append(endpoints, endpoint)
+			endpoint.Handler = funcName(funcDecl)
+			endpoint.Package = file.Name.Name
+			result.Endpoints = append(result.Endpoints, endpoint)
 		}
 	}
 
-	return endpoints
-}
+	// 函数声明以外带有 @Router 的注释，如赋值给变量或由工厂函数返回的匿名函数
+	for _, group := range file.Comments {
+		if docs[group] || !hasRouter(commentLines(fset, group)) {
+			continue
+		}
 
-// parseComments 解析注释
-func (p *Parser) parseComments(doc *ast.CommentGroup, filePath string, line int) *Endpoint {
-	if doc == nil {
-		return nil
-	}
+		handler, pos, ok := annotatedFuncLit(fset, file, group)
+		if !ok {
+			continue
+		}
 
-	endpoint := &Endpoint{
-		File:       filePath,
-		Line:       line,
-		Tags:       make([]string, 0),
-		Parameters: make([]Parameter, 0),
-		Responses:  make(map[string]Response),
+		endpoint, diagnostics := p.parseComments(fset, group, filePath, fset.Position(pos).Line)
+		result.Diagnostics = append(result.Diagnostics, diagnostics...)
+		if endpoint != nil {
+			endpoint.Handler = handler
+			endpoint.Package = file.Name.Name
+			result.Endpoints = append(result.Endpoints, endpoint)
+		}
 	}
 
-	hasRouter := false
+	// 端点按源码顺序排列，冲突处理依赖出现顺序
+	sort.SliceStable(result.Endpoints, func(i, j int) bool {
+		return result.Endpoints[i].Line < result.Endpoints[j].Line
+	})
 
-	// 遍历所有注释行
-	for _, comment := range doc.List {
-		text := comment.Text
-		// 移除注释前缀 //
-		text = strings.TrimPrefix(text, "//")
-		text = strings.TrimSpace(text)
+	return result
+}
 
-		// 解析 @Router 标签
-		if router := parseRouterTag(text); router != nil {
-			endpoint.Method = router.Method
-			endpoint.Path = router.Path
-			hasRouter = true
+// hasRouter 判断注释行中是否有 @Router 标签
+func hasRouter(lines []commentLine) bool {
+	for _, line := range lines {
+		text := strings.TrimSpace(strings.TrimPrefix(line.Text, "//"))
+		if strings.EqualFold(tagName(text), "@Router") {
+			return true
 		}
+	}
+	return false
+}
 
-		// 解析 @Summary 标签
-		if summary := parseTag(text, "@Summary"); summary != "" {
-			endpoint.Summary = summary
+// annotatedFuncLit 查找紧跟在注释组之后的匿名函数，返回处理函数名与所在位置。
+// 匿名函数赋值给变量时使用变量名，由 return 返回时使用外层函数名，如工厂函数 GetUser
+func annotatedFuncLit(fset *token.FileSet, file *ast.File, group *ast.CommentGroup) (string, token.Pos, bool) {
+	line := fset.Position(group.End()).Line + 1
+
+	var (
+		owner *ast.FuncDecl
+		name  string
+		pos   token.Pos
+		found bool
+	)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if found || n == nil || fset.Position(n.End()).Line < line {
+			return false
 		}
-
-		// 解析 @Description 标签
-		if desc := parseTag(text, "@Description"); desc != "" {
-			endpoint.Description = desc
+		if decl, ok := n.(*ast.FuncDecl); ok {
+			owner = decl
 		}
-
-		// 解析 @Tags 标签
-		if tags := parseTag(text, "@Tags"); tags != "" {
-			endpoint.Tags = append(endpoint.Tags, tags)
+		if fset.Position(n.Pos()).Line != line {
+			return true
 		}
 
-		// 解析 @Param 标签
-		if param := parseParamTag(text); param != nil {
-			endpoint.Parameters = append(endpoint.Parameters, *param)
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			for i, value := range node.Values {
+				if isFuncLit(value) && i < len(node.Names) {
+					name, found = node.Names[i].Name, true
+					break
+				}
+			}
+		case *ast.AssignStmt:
+			for i, value := range node.Rhs {
+				if isFuncLit(value) && i < len(node.Lhs) {
+					name, found = exprName(node.Lhs[i]), true
+					break
+				}
+			}
+		case *ast.ReturnStmt:
+			for _, value := range node.Results {
+				if isFuncLit(value) && owner != nil {
+					name, found = funcName(owner), true
+					break
+				}
+			}
 		}
 
-		// 解析 @Success 标签
-		if resp := parseResponseTag(text, "@Success"); resp != nil {
-			endpoint.Responses[resp.StatusCode] = *resp
+		if found {
+			pos = n.Pos()
 		}
+		return !found
+	})
 
-		// 解析 @Failure 标签
-		if resp := parseResponseTag(text, "@Failure"); resp != nil {
-			endpoint.Responses[resp.StatusCode] = *resp
-		}
+	return name, pos, found
+}
 
-		// 解析 @Deprecated 标签
-		if strings.Contains(text, "@Deprecated") {
-			endpoint.Deprecated = true
-		}
+// isFuncLit 判断表达式是否为匿名函数，允许类型转换，如 gin.HandlerFunc(func(c *gin.Context) {...})
+func isFuncLit(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return true
+	case *ast.ParenExpr:
+		return isFuncLit(e.X)
+	case *ast.CallExpr:
+		return len(e.Args) == 1 && isFuncLit(e.Args[0])
 	}
+	return false
+}
 
-	// 只返回有 @Router 标签的端点
-	if !hasRouter {
-		return nil
+// exprName 返回赋值目标的名称，如 getUser 或 h.getUser 中的 getUser
+func exprName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
 	}
-
-	return endpoint
+	return ""
 }
 
-// parseRouterTag 解析 @Router 标签
-func parseRouterTag(text string) *struct {
-	Method string
-	Path   string
-} {
-	// 格式: @Router /api/users [GET]
-	if !strings.Contains(text, "@Router") {
-		return nil
+// resolveTypes 把端点中引用的类型名解析为全限定类型标识，无法解析的类型返回诊断信息
+func (p *Parser) resolveTypes(endpoint *Endpoint, imports []Import, pkgPath string) []Diagnostic {
+	var diagnostics []Diagnostic
+	unresolved := func(tag string) func(name string, external bool) {
+		return func(name string, external bool) {
+			d := Diagnostic{
+				File:     endpoint.File,
+				Line:     endpoint.Line,
+				Tag:      tag,
+				Message:  fmt.Sprintf("无法解析类型 %q", name),
+				Severity: SeverityError,
+			}
+			if external {
+				// 模块外的包没有源码，文档中按 object 处理
+				d.Message = fmt.Sprintf("类型 %q 不在模块中，按 object 处理", name)
+				d.Severity = SeverityWarning
+			}
+			diagnostics = append(diagnostics, d)
+		}
 	}
 
-	// 使用正则表达式解析
-	re := regexp.MustCompile(`@Router\s+(\S+)\s+\[(\w+)\]`)
-	matches := re.FindStringSubmatch(text)
-
-	if len(matches) < 3 {
-		return nil
+	for i := range endpoint.Parameters {
+		p.resolveSchema(endpoint.Parameters[i].Schema, imports, pkgPath, unresolved("@Param"))
 	}
 
-	return &struct {
-		Method string
-		Path   string
-	}{
-		Method: matches[2],
-		Path:   matches[1],
+	codes := make([]string, 0, len(endpoint.Responses))
+	for code := range endpoint.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		tag := "@Success"
+		if code >= "400" {
+			tag = "@Failure"
+		}
+		p.resolveSchema(endpoint.Responses[code].Schema, imports, pkgPath, unresolved(tag))
 	}
+
+	return diagnostics
 }
 
-// parseTag 解析简单标签
-func parseTag(text, tag string) string {
-	if !strings.Contains(text, tag) {
-		return ""
+// builtinType 判断类型是否为生成文档时直接映射的内置类型，如 time.Time、map[string]int
+func builtinType(name string) bool {
+	name = strings.TrimLeft(strings.TrimSpace(name), "*")
+	if strings.HasPrefix(name, "[]") {
+		return builtinType(name[2:])
 	}
-
-	// 提取标签后的内容
-	idx := strings.Index(text, tag)
-	if idx == -1 {
-		return ""
+	if _, value, ok := splitMapType(name); ok {
+		return builtinType(value)
 	}
 
-	content := text[idx+len(tag):]
-	// 移除前导空格
-	content = strings.TrimSpace(content)
-
-	return content
+	switch name {
+	case "byte", "rune", "error", "time.Time", "time.Duration":
+		return true
+	}
+	return primitiveType(name) != ""
 }
 
-// parseParamTag 解析 @Param 标签
-func parseParamTag(text string) *Parameter {
-	if !strings.Contains(text, "@Param") {
-		return nil
+// resolveSchema 递归解析数据模型中的类型引用，无法解析的保持原样并调用 unresolved
+func (p *Parser) resolveSchema(schema *Schema, imports []Import, pkgPath string, unresolved func(name string, external bool)) {
+	if schema == nil {
+		return
 	}
 
-	// 简单的解析逻辑
-	// 格式: @Param page query int false "Page number"
-	return nil
+	if schema.Ref != "" {
+		if id, ok := p.types.resolveType(schema.Ref, imports, pkgPath); ok {
+			schema.Ref = id
+		} else {
+			p.logger.Debug("无法解析类型", zap.String("type", schema.Ref), zap.String("package", pkgPath))
+			if !builtinType(schema.Ref) {
+				unresolved(schema.Ref, p.types.External(schema.Ref, imports))
+			}
+		}
+	}
+
+	p.resolveSchema(schema.Items, imports, pkgPath, unresolved)
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p.resolveSchema(schema.Properties[name], imports, pkgPath, unresolved)
+	}
 }
 
-// parseResponseTag 解析响应标签
-func parseResponseTag(text, tag string) *Response {
-	if !strings.Contains(text, tag) {
-		return nil
+// parseComments 解析注释，line 为函数声明所在的行
+func (p *Parser) parseComments(fset *token.FileSet, doc *ast.CommentGroup, filePath string, line int) (*Endpoint, []Diagnostic) {
+	if doc == nil {
+		return nil, nil
 	}
 
-	// 简单的解析逻辑
-	// 格式: @Success 200 {object} User
-	return nil
+	return p.comments.parseEndpoint(commentLines(fset, doc), filePath, line)
 }
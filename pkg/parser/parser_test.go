@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -82,6 +84,111 @@ func GetUsers() {
 	assert.Equal(t, "Get all users", endpoints[0].Summary)
 }
 
+func TestParserParseFileClosures(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	parser := NewParser(&config.Config{}, logger)
+
+	testFile := filepath.Join(t.TempDir(), "user.go")
+	content := `package api
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+// @Router /users/{id} [DELETE]
+func (h *UserHandler) DeleteUser(c *gin.Context) {}
+
+// @Router /users [GET]
+var ListUsers = func(c *gin.Context) {}
+
+// @Router /users [POST]
+var CreateUser = gin.HandlerFunc(func(c *gin.Context) {})
+
+// GetUser 返回获取用户的处理函数
+func (h *UserHandler) GetUser() gin.HandlerFunc {
+	// @Summary Get a user
+	// @Router /users/{id} [GET]
+	return func(c *gin.Context) {}
+}
+
+func register(h *UserHandler) {
+	var update gin.HandlerFunc
+
+	// @Router /users/{id} [PUT]
+	update = func(c *gin.Context) {}
+
+	// 没有紧跟匿名函数的注释不是端点
+	// @Router /ignored [GET]
+	_ = update
+}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	endpoints, err := parser.ParseFile(testFile)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 5)
+
+	var got []string
+	for _, endpoint := range endpoints {
+		got = append(got, fmt.Sprintf("%s %s %s:%d", endpoint.Method, endpoint.Path, endpoint.Handler, endpoint.Line))
+	}
+	assert.Equal(t, []string{
+		"DELETE /users/{id} UserHandler.DeleteUser:8",
+		"GET /users ListUsers:11",
+		"POST /users CreateUser:14",
+		"GET /users/{id} UserHandler.GetUser:20",
+		"PUT /users/{id} update:27",
+	}, got)
+	assert.Equal(t, "Get a user", endpoints[3].Summary)
+	assert.Equal(t, "api", endpoints[3].Package)
+}
+
+func TestParserParseFileBlockComments(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	parser := NewParser(&config.Config{}, logger)
+
+	testFile := filepath.Join(t.TempDir(), "user.go")
+	content := `package api
+
+/*
+GetUsers 获取用户列表
+
+	@Summary	List users
+	@Description	Lists the users,
+	newest first.
+	@Param	page	query	int	false	"page"	minimum(x)
+	@Router	/users [GET]
+*/
+func GetUsers() {}
+
+/**
+ * @Router /users [POST]
+ * @Tags User
+ */
+var CreateUser = func() {}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	endpoints, err := parser.ParseFile(testFile)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	assert.Equal(t, "GET", endpoints[0].Method)
+	assert.Equal(t, "List users", endpoints[0].Summary)
+	assert.Equal(t, "Lists the users,\nnewest first.", endpoints[0].Description)
+	assert.Equal(t, 12, endpoints[0].Line)
+
+	assert.Equal(t, "POST", endpoints[1].Method)
+	assert.Equal(t, []string{"User"}, endpoints[1].Tags)
+	assert.Equal(t, "CreateUser", endpoints[1].Handler)
+
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "@Param", diagnostics[0].Tag)
+	assert.Equal(t, 9, diagnostics[0].Line)
+	assert.Equal(t, 2, diagnostics[0].Column)
+}
+
 func TestParserParseProject(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	cfg := &config.Config{}
@@ -194,3 +301,75 @@ func GetUsers() {
 	// 验证结果（没有 @Router 标签，应该返回空）
 	assert.Len(t, endpoints, 0)
 }
+
+func TestParseCommentsParamsAndResponses(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cfg := &config.Config{}
+	parser := NewParser(cfg, logger)
+
+	// 创建临时文件
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.go")
+
+	content := `package main
+
+// @Router /api/users [GET]
+// @Summary 获取所有用户
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {array} User "成功"
+// @Failure 400 {object} ErrorResponse "请求错误"
+func GetUsers() {
+}
+`
+
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	require.NoError(t, err)
+
+	// 解析文件
+	endpoints, err := parser.ParseFile(testFile)
+	require.NoError(t, err)
+
+	// 验证结果
+	require.Len(t, endpoints, 1)
+	endpoint := endpoints[0]
+	require.Len(t, endpoint.Parameters, 2)
+	assert.Equal(t, "page", endpoint.Parameters[0].Name)
+	assert.Equal(t, "query", endpoint.Parameters[0].In)
+	assert.Equal(t, "int", endpoint.Parameters[0].Type)
+	assert.Equal(t, "页码", endpoint.Parameters[0].Description)
+	require.Len(t, endpoint.Responses, 2)
+	assert.Equal(t, "成功", endpoint.Responses["200"].Description)
+	assert.Equal(t, "array", endpoint.Responses["200"].Schema.Type)
+	assert.Equal(t, "User", endpoint.Responses["200"].Schema.Items.Ref)
+	assert.Equal(t, "请求错误", endpoint.Responses["400"].Description)
+}
+
+func TestParserParseProjectContextOrderAndCancel(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cfg := &config.Config{Parser: config.ParserConfig{MaxConcurrent: 2}}
+
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		content := "package api\n\n// @Router /" + name + " [get]\nfunc Handle() {}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name+".go"), []byte(content), 0644))
+	}
+
+	for i := 0; i < 3; i++ {
+		endpoints, err := NewParser(cfg, logger).ParseProjectContext(context.Background(), tmpDir)
+		require.NoError(t, err)
+
+		paths := make([]string, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			paths = append(paths, endpoint.Path)
+		}
+		assert.Equal(t, []string{"/a", "/b", "/c", "/d", "/e"}, paths)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	endpoints, err := NewParser(cfg, logger).ParseProjectContext(ctx, tmpDir)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, endpoints)
+}
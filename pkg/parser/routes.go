@@ -0,0 +1,996 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Route 代表从路由注册代码中发现的路由
+type Route struct {
+	Method  string
+	Path    string
+	Handler *FuncInfo // 处理函数声明，无法静态解析时为 nil
+	File    string    // 路由注册所在文件
+	Line    int
+
+	// Unresolved 是无法确定接收者类型的方法值处理函数，如 h.GetUser。
+	// 不为空时路由不写入文档，避免使用其他类型同名方法的注释
+	Unresolved string
+
+	node     *routerNode // 注册路由的路由器，挂载关系在遍历结束后才确定
+	relative string      // 相对于路由器的路径
+}
+
+// RouteCall 代表对路由器的一次路由注册调用
+type RouteCall struct {
+	Method  string
+	Path    string
+	Handler ast.Expr
+}
+
+// Subrouter 代表挂载到路由器上的子路由器
+type Subrouter struct {
+	Prefix string
+	// Router 是回调函数字面量、返回路由器的调用，或路由器变量
+	Router ast.Expr
+}
+
+// RouteAdapter 从某个 Web 框架的路由注册代码中静态提取路由
+type RouteAdapter interface {
+	// Name 返回框架名
+	Name() string
+	// IsRouterType 判断类型表达式是否为该框架的路由器类型，用于识别函数参数
+	IsRouterType(expr ast.Expr, imports map[string]string) bool
+	// IsRoot 判断表达式是否创建或代表一个根路由器
+	IsRoot(expr ast.Expr, imports map[string]string) bool
+	// Group 判断对路由器的方法调用是否返回子路由器，返回其相对前缀
+	Group(call *ast.CallExpr) (string, bool)
+	// Subrouters 返回对路由器的方法调用所挂载的子路由器，如 chi 的 Route 与 Mount
+	Subrouters(call *ast.CallExpr) []Subrouter
+	// Routes 返回对路由器的方法调用所注册的路由
+	Routes(call *ast.CallExpr) []RouteCall
+	// OpenAPIPath 把框架的路径语法转换为 OpenAPI 路径模板
+	OpenAPIPath(path string) string
+}
+
+// routeAdapters 按配置中的框架名索引路由适配器
+var routeAdapters = map[string]func() RouteAdapter{
+	"gin":     func() RouteAdapter { return NewGinAdapter() },
+	"nethttp": func() RouteAdapter { return NewNetHTTPAdapter() },
+	"chi":     func() RouteAdapter { return NewChiAdapter() },
+	"echo":    func() RouteAdapter { return NewEchoAdapter() },
+}
+
+// routeAdapterAliases 是框架名的别名
+var routeAdapterAliases = map[string]string{
+	"net/http": "nethttp",
+	"http":     "nethttp",
+}
+
+// RouteAdapterNames 返回支持的框架名，按名称排序
+func RouteAdapterNames() []string {
+	names := make([]string, 0, len(routeAdapters))
+	for name := range routeAdapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRouteAdapter 根据框架名创建路由适配器
+func NewRouteAdapter(name string) (RouteAdapter, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := routeAdapterAliases[name]; ok {
+		name = alias
+	}
+
+	factory, ok := routeAdapters[name]
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
+}
+
+// pathParamPattern 匹配 OpenAPI 路径模板中的参数
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// routerNode 代表一个路由器及其相对于父路由器的前缀
+type routerNode struct {
+	parent *routerNode
+	prefix string
+}
+
+// path 返回路由器的完整前缀
+func (n *routerNode) path() string {
+	if n == nil {
+		return ""
+	}
+	return joinRoutePath(n.parent.path(), n.prefix)
+}
+
+// routeWalker 遍历函数体，跟踪绑定到路由器的变量及其前缀，收集路由注册
+type routeWalker struct {
+	logger   *zap.Logger
+	types    *TypeRegistry
+	adapter  RouteAdapter
+	routes   []*Route
+	visiting map[*ast.FuncDecl]bool
+	reached  map[*ast.FuncDecl]bool
+	handled  map[*ast.FuncLit]bool
+}
+
+// walkScope 是一次函数体遍历的上下文
+type walkScope struct {
+	fn      *FuncInfo
+	imports map[string]string
+	env     map[string]*routerNode
+	vars    map[string]namedType // 类型已知的变量，用于按接收者类型解析方法值，如 h.Get
+	base    *routerNode          // 非空时，函数内创建的根路由器即为挂载点
+}
+
+// namedType 代表包中的一个命名类型
+type namedType struct {
+	pkg  *Package
+	name string
+}
+
+// method 返回以该类型为接收者的方法，没有时返回 nil
+func (t namedType) method(name string) *FuncInfo {
+	for _, fn := range t.pkg.Methods[name] {
+		if recvTypeName(fn.Decl) == t.name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// newRouteWalker 创建一个路由遍历器
+func newRouteWalker(logger *zap.Logger, types *TypeRegistry, adapter RouteAdapter) *routeWalker {
+	return &routeWalker{
+		logger:   logger,
+		types:    types,
+		adapter:  adapter,
+		visiting: make(map[*ast.FuncDecl]bool),
+		reached:  make(map[*ast.FuncDecl]bool),
+		handled:  make(map[*ast.FuncLit]bool),
+	}
+}
+
+// discover 从给定包中发现路由。没有路由器参数与返回值的函数作为入口遍历；
+// 其余函数没有被已知调用方到达时，按根路由器遍历
+func (w *routeWalker) discover(pkgs []*Package) []*Route {
+	var deferred []*FuncInfo
+
+	for _, pkg := range pkgs {
+		for _, fn := range packageFuncs(pkg) {
+			if fn.Decl.Body == nil {
+				continue
+			}
+			if len(w.routerParams(fn)) > 0 || w.returnsRouter(fn) {
+				deferred = append(deferred, fn)
+				continue
+			}
+			w.walkFunc(fn, nil, nil)
+		}
+	}
+
+	for _, fn := range deferred {
+		if w.reached[fn.Decl] {
+			continue
+		}
+		bindings := make(map[string]*routerNode)
+		for _, name := range w.routerParams(fn) {
+			bindings[name] = &routerNode{}
+		}
+		w.walkFunc(fn, bindings, nil)
+	}
+
+	return w.resolveRoutes()
+}
+
+// resolveRoutes 在挂载关系确定后计算路由的完整路径，并按方法与路径去重
+func (w *routeWalker) resolveRoutes() []*Route {
+	seen := make(map[string]bool)
+	routes := make([]*Route, 0, len(w.routes))
+
+	for _, route := range w.routes {
+		route.Path = w.adapter.OpenAPIPath(joinRoutePath(route.node.path(), route.relative))
+
+		key := route.Method + " " + route.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+// packageFuncs 按源码位置顺序返回包中的函数与方法
+func packageFuncs(pkg *Package) []*FuncInfo {
+	var funcs []*FuncInfo
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				funcs = append(funcs, &FuncInfo{Decl: funcDecl, File: file, Pkg: pkg})
+			}
+		}
+	}
+	return funcs
+}
+
+// routerParams 返回函数中类型为路由器的参数名
+func (w *routeWalker) routerParams(fn *FuncInfo) []string {
+	return w.routerFields(fn.Decl.Type.Params, fileImports(fn.File))
+}
+
+// routerFields 返回参数列表中类型为路由器的参数名
+func (w *routeWalker) routerFields(fields *ast.FieldList, imports map[string]string) []string {
+	if fields == nil {
+		return nil
+	}
+
+	var names []string
+	for _, field := range fields.List {
+		if !w.adapter.IsRouterType(field.Type, imports) {
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+
+	return names
+}
+
+// returnsRouter 判断函数是否返回路由器
+func (w *routeWalker) returnsRouter(fn *FuncInfo) bool {
+	results := fn.Decl.Type.Results
+	if results == nil {
+		return false
+	}
+
+	imports := fileImports(fn.File)
+	for _, field := range results.List {
+		if w.adapter.IsRouterType(field.Type, imports) {
+			return true
+		}
+	}
+	return false
+}
+
+// walkFunc 在给定的参数绑定下遍历函数体
+func (w *routeWalker) walkFunc(fn *FuncInfo, bindings map[string]*routerNode, base *routerNode) {
+	if w.visiting[fn.Decl] {
+		return
+	}
+	w.visiting[fn.Decl] = true
+	defer delete(w.visiting, fn.Decl)
+
+	scope := &walkScope{
+		fn:      fn,
+		imports: fileImports(fn.File),
+		env:     make(map[string]*routerNode, len(bindings)),
+		vars:    make(map[string]namedType),
+		base:    base,
+	}
+	for name, node := range bindings {
+		scope.env[name] = node
+	}
+	w.bindVars(fn.Decl.Recv, scope)
+	w.bindVars(fn.Decl.Type.Params, scope)
+
+	w.walkBody(fn.Decl.Body, scope)
+}
+
+// walkBody 遍历语句块。函数字面量在继承外层变量的新作用域中遍历
+func (w *routeWalker) walkBody(body *ast.BlockStmt, scope *walkScope) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			if !w.handled[node] {
+				w.walkFuncLit(node, scope, nil)
+			}
+			return false
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					break
+				}
+				if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+					if router, ok := w.routerOf(rhs, scope); ok {
+						scope.env[ident.Name] = router
+					} else if t, ok := w.valueType(rhs, scope); ok {
+						scope.vars[ident.Name] = t
+					} else {
+						delete(scope.vars, ident.Name)
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if t, ok := w.typeOf(node.Type, scope.imports, scope.fn.Pkg); ok {
+				for _, name := range node.Names {
+					scope.vars[name.Name] = t
+				}
+			}
+			for i, value := range node.Values {
+				if i >= len(node.Names) {
+					break
+				}
+				if router, ok := w.routerOf(value, scope); ok {
+					scope.env[node.Names[i].Name] = router
+				} else if t, ok := w.valueType(value, scope); ok && node.Type == nil {
+					scope.vars[node.Names[i].Name] = t
+				}
+			}
+		case *ast.CallExpr:
+			w.visitCall(node, scope)
+		}
+		return true
+	})
+}
+
+// walkFuncLit 遍历函数字面量，参数会遮蔽外层同名变量；
+// router 非空时绑定到第一个路由器类型的参数
+func (w *routeWalker) walkFuncLit(lit *ast.FuncLit, outer *walkScope, router *routerNode) {
+	scope := &walkScope{
+		fn:      outer.fn,
+		imports: outer.imports,
+		env:     make(map[string]*routerNode, len(outer.env)),
+		vars:    make(map[string]namedType, len(outer.vars)),
+		base:    outer.base,
+	}
+	for name, node := range outer.env {
+		scope.env[name] = node
+	}
+	for name, t := range outer.vars {
+		scope.vars[name] = t
+	}
+	for _, name := range flattenParams(lit.Type.Params) {
+		delete(scope.env, name)
+	}
+	w.bindVars(lit.Type.Params, scope)
+
+	if router != nil {
+		if names := w.routerFields(lit.Type.Params, outer.imports); len(names) > 0 {
+			scope.env[names[0]] = router
+		}
+	}
+
+	w.walkBody(lit.Body, scope)
+}
+
+// bindVars 记录参数或接收者中类型为模块内命名类型的变量，其余同名变量被遮蔽
+func (w *routeWalker) bindVars(fields *ast.FieldList, scope *walkScope) {
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		t, ok := w.typeOf(field.Type, scope.imports, scope.fn.Pkg)
+		for _, name := range field.Names {
+			if ok {
+				scope.vars[name.Name] = t
+			} else {
+				delete(scope.vars, name.Name)
+			}
+		}
+	}
+}
+
+// typeOf 把类型表达式解析为模块内的命名类型，如 *UserHandler、api.UserHandler
+func (w *routeWalker) typeOf(expr ast.Expr, imports map[string]string, pkg *Package) (namedType, bool) {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return w.typeOf(e.X, imports, pkg)
+	case *ast.ParenExpr:
+		return w.typeOf(e.X, imports, pkg)
+	case *ast.IndexExpr:
+		return w.typeOf(e.X, imports, pkg)
+	case *ast.IndexListExpr:
+		return w.typeOf(e.X, imports, pkg)
+	case *ast.Ident:
+		if _, ok := pkg.Types[e.Name]; ok {
+			return namedType{pkg: pkg, name: e.Name}, true
+		}
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		if importPath, ok := imports[x.Name]; ok {
+			if other := w.types.PackageByPath(importPath); other != nil {
+				if _, ok := other.Types[e.Sel.Name]; ok {
+					return namedType{pkg: other, name: e.Sel.Name}, true
+				}
+			}
+		}
+	}
+	return namedType{}, false
+}
+
+// valueType 推断表达式的值的类型：类型已知的局部变量与包级变量、结构体字段、复合字面量、
+// new(T)，或返回命名类型的构造函数调用，如 api.NewUserHandler(db)
+func (w *routeWalker) valueType(expr ast.Expr, scope *walkScope) (namedType, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return w.valueType(e.X, scope)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return w.valueType(e.X, scope)
+		}
+	case *ast.Ident:
+		if t, ok := scope.vars[e.Name]; ok {
+			return t, true
+		}
+		return w.packageVarType(e.Name, scope.fn.Pkg)
+	case *ast.CompositeLit:
+		return w.typeOf(e.Type, scope.imports, scope.fn.Pkg)
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+			return w.typeOf(e.Args[0], scope.imports, scope.fn.Pkg)
+		}
+		callee := w.resolveFunc(e.Fun, scope)
+		if callee != nil && callee.Decl.Type.Results != nil && len(callee.Decl.Type.Results.List) > 0 {
+			return w.typeOf(callee.Decl.Type.Results.List[0].Type, fileImports(callee.File), callee.Pkg)
+		}
+	case *ast.SelectorExpr:
+		if t, ok := w.valueType(e.X, scope); ok {
+			return w.fieldType(t, e.Sel.Name)
+		}
+	}
+	return namedType{}, false
+}
+
+// fieldType 返回结构体类型中字段的类型，如 s.users 的类型
+func (w *routeWalker) fieldType(t namedType, name string) (namedType, bool) {
+	decl := t.pkg.Types[t.name]
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		return namedType{}, false
+	}
+
+	for _, field := range st.Fields.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return w.typeOf(field.Type, fileImports(decl.File), t.pkg)
+			}
+		}
+	}
+	return namedType{}, false
+}
+
+// packageVarType 返回包级变量的类型，来自变量声明的类型或初始值
+func (w *routeWalker) packageVarType(name string, pkg *Package) (namedType, bool) {
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, ident := range valueSpec.Names {
+					if ident.Name != name {
+						continue
+					}
+
+					imports := fileImports(file)
+					if valueSpec.Type != nil {
+						return w.typeOf(valueSpec.Type, imports, pkg)
+					}
+					if i < len(valueSpec.Values) {
+						scope := &walkScope{fn: &FuncInfo{File: file, Pkg: pkg}, imports: imports}
+						return w.valueType(valueSpec.Values[i], scope)
+					}
+					return namedType{}, false
+				}
+			}
+		}
+	}
+	return namedType{}, false
+}
+
+// visitCall 处理一次调用：路由注册、子路由器挂载，或把路由器传给其他函数
+func (w *routeWalker) visitCall(call *ast.CallExpr, scope *walkScope) {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if router, ok := w.routerOf(sel.X, scope); ok {
+			for _, rc := range w.adapter.Routes(call) {
+				w.addRoute(rc, router, call, scope)
+			}
+			for _, sub := range w.adapter.Subrouters(call) {
+				w.mount(sub, router, scope)
+			}
+			return
+		}
+	}
+
+	callee := w.resolveFunc(call.Fun, scope)
+	if callee == nil || callee.Decl.Body == nil {
+		return
+	}
+
+	bindings := make(map[string]*routerNode)
+	params := flattenParams(callee.Decl.Type.Params)
+	for i, arg := range call.Args {
+		if i >= len(params) {
+			break
+		}
+		if router, ok := w.routerOf(arg, scope); ok {
+			bindings[params[i]] = router
+		}
+	}
+
+	if len(bindings) > 0 {
+		w.reached[callee.Decl] = true
+		w.walkFunc(callee, bindings, nil)
+	}
+}
+
+// mount 把子路由器挂载到路由器上
+func (w *routeWalker) mount(sub Subrouter, parent *routerNode, scope *walkScope) {
+	child := &routerNode{parent: parent, prefix: sub.Prefix}
+
+	switch expr := sub.Router.(type) {
+	case *ast.FuncLit:
+		w.handled[expr] = true
+		w.walkFuncLit(expr, scope, child)
+		return
+	case *ast.Ident:
+		// 已经注册过路由的路由器变量，挂载后其路由的前缀随之改变
+		if node, ok := scope.env[expr.Name]; ok {
+			if node.parent == nil && node != parent {
+				node.parent, node.prefix = parent, sub.Prefix
+			}
+			return
+		}
+	}
+
+	target := sub.Router
+	if call, ok := target.(*ast.CallExpr); ok {
+		target = call.Fun
+	}
+
+	callee := w.resolveFunc(target, scope)
+	if callee == nil || callee.Decl.Body == nil {
+		return
+	}
+
+	// 以路由器为参数的函数，如 r.Route("/users", userRoutes)
+	if params := w.routerParams(callee); len(params) > 0 {
+		w.reached[callee.Decl] = true
+		w.walkFunc(callee, map[string]*routerNode{params[0]: child}, nil)
+		return
+	}
+
+	// 返回路由器的函数，如 r.Mount("/admin", adminRouter())
+	if w.returnsRouter(callee) {
+		w.reached[callee.Decl] = true
+		w.walkFunc(callee, nil, child)
+	}
+}
+
+// flattenParams 按位置返回参数名，未命名参数记为 "_"
+func flattenParams(fields *ast.FieldList) []string {
+	var names []string
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			names = append(names, "_")
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// routerOf 判断表达式是否为路由器，并返回对应的路由器节点
+func (w *routeWalker) routerOf(expr ast.Expr, scope *walkScope) (*routerNode, bool) {
+	if w.adapter.IsRoot(expr, scope.imports) {
+		if scope.base != nil {
+			return scope.base, true
+		}
+		return &routerNode{}, true
+	}
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return w.routerOf(e.X, scope)
+	case *ast.UnaryExpr:
+		return w.routerOf(e.X, scope)
+	case *ast.Ident:
+		if node, ok := scope.env[e.Name]; ok {
+			return node, true
+		}
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok {
+			if parent, ok := w.routerOf(sel.X, scope); ok {
+				if sub, ok := w.adapter.Group(e); ok {
+					return &routerNode{parent: parent, prefix: sub}, true
+				}
+				return nil, false
+			}
+		}
+		// 返回路由器的函数，如 func NewRouter() *gin.Engine
+		if callee := w.resolveFunc(e.Fun, scope); callee != nil && w.returnsRouter(callee) {
+			node := &routerNode{}
+			w.reached[callee.Decl] = true
+			w.walkFunc(callee, nil, node)
+			return node, true
+		}
+	}
+
+	return nil, false
+}
+
+// addRoute 记录一条路由，完整路径在遍历结束后计算
+func (w *routeWalker) addRoute(rc RouteCall, router *routerNode, call *ast.CallExpr, scope *walkScope) {
+	pos := w.types.Position(call.Pos())
+
+	route := &Route{
+		Method:   strings.ToUpper(rc.Method),
+		Handler:  w.resolveHandler(rc.Handler, scope),
+		File:     pos.Filename,
+		Line:     pos.Line,
+		node:     router,
+		relative: rc.Path,
+	}
+	if route.Handler == nil {
+		route.Unresolved = methodValue(rc.Handler, scope)
+	}
+	w.routes = append(w.routes, route)
+}
+
+// methodValue 返回作为处理函数的方法值或方法调用，如 h.GetUser、h.List()，
+// 也检查 http.HandlerFunc(h.GetUser) 这样的类型转换；导入包的函数与其他表达式返回空字符串
+func methodValue(expr ast.Expr, scope *walkScope) string {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if name := methodValue(call.Fun, scope); name != "" {
+			return name + "()"
+		}
+		if len(call.Args) == 1 {
+			return methodValue(call.Args[0], scope)
+		}
+		return ""
+	}
+
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if x, ok := sel.X.(*ast.Ident); ok {
+		if _, imported := scope.imports[x.Name]; imported {
+			return ""
+		}
+	}
+	return selectorString(sel)
+}
+
+// selectorString 返回选择器表达式的源码形式，如 s.users.Get
+func selectorString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return selectorString(e.X) + "." + e.Sel.Name
+	case *ast.CallExpr:
+		return selectorString(e.Fun) + "()"
+	case *ast.ParenExpr:
+		return selectorString(e.X)
+	case *ast.StarExpr:
+		return "*" + selectorString(e.X)
+	case *ast.UnaryExpr:
+		return e.Op.String() + selectorString(e.X)
+	}
+	return "…"
+}
+
+// resolveHandler 把处理函数表达式解析为函数声明；对返回处理函数的工厂调用，
+// 解析为工厂函数；对 http.HandlerFunc(f) 这样的类型转换，解析为被转换的函数
+func (w *routeWalker) resolveHandler(expr ast.Expr, scope *walkScope) *FuncInfo {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if handler := w.resolveFunc(call.Fun, scope); handler != nil {
+			return handler
+		}
+		if len(call.Args) == 1 {
+			return w.resolveFunc(call.Args[0], scope)
+		}
+		return nil
+	}
+	return w.resolveFunc(expr, scope)
+}
+
+// resolveFunc 解析被调用的函数：同包函数、导入包的函数，或接收者类型已知的方法。
+// 接收者类型无法确定时返回 nil，不按方法名猜测
+func (w *routeWalker) resolveFunc(expr ast.Expr, scope *walkScope) *FuncInfo {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return scope.fn.Pkg.Funcs[e.Name]
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if _, local := scope.vars[x.Name]; !local {
+				if importPath, ok := scope.imports[x.Name]; ok {
+					if pkg := w.types.PackageByPath(importPath); pkg != nil {
+						return pkg.Funcs[e.Sel.Name]
+					}
+					return nil
+				}
+			}
+		}
+		if t, ok := w.valueType(e.X, scope); ok {
+			return t.method(e.Sel.Name)
+		}
+	}
+	return nil
+}
+
+// joinRoutePath 拼接路由前缀与相对路径，保留相对路径末尾的斜杠
+func joinRoutePath(prefix, relative string) string {
+	if relative == "" {
+		if prefix == "" {
+			return "/"
+		}
+		return prefix
+	}
+
+	joined := path.Join("/", prefix, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
+// fileImports 返回文件中导入包的本地名到导入路径的映射
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string, len(file.Imports))
+
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := defaultImportName(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = importPath
+	}
+
+	return imports
+}
+
+// defaultImportName 返回导入路径默认的包名，跳过 /v2 这样的主版本后缀
+func defaultImportName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+
+	if len(parts) > 1 && len(name) > 1 && name[0] == 'v' {
+		if _, err := strconv.Atoi(name[1:]); err == nil {
+			name = parts[len(parts)-2]
+		}
+	}
+
+	return name
+}
+
+// stringLit 返回字符串字面量的值
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return "", false
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// isPackageSelector 判断表达式是否为 pkg.Name 形式，且 pkg 导入自给定路径之一
+func isPackageSelector(expr ast.Expr, imports map[string]string, importPaths []string, names ...string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	x, ok := sel.X.(*ast.Ident)
+	if !ok || !isPackageIdent(x, imports, importPaths) {
+		return false
+	}
+
+	for _, name := range names {
+		if sel.Sel.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isPackageIdent 判断标识符是否为导入自给定路径之一的包名
+func isPackageIdent(ident *ast.Ident, imports map[string]string, importPaths []string) bool {
+	importPath, ok := imports[ident.Name]
+	if !ok {
+		return false
+	}
+
+	for _, candidate := range importPaths {
+		if importPath == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverRoutes 从项目的路由注册代码中发现路由，并转换为端点。
+// 处理函数上已有 @Router 的路由由注释决定，这里跳过
+func (p *Parser) discoverRoutes(files []string, adapters []RouteAdapter) []*Endpoint {
+	dirs := make(map[string]bool)
+	for _, file := range files {
+		dirs[filepath.Dir(file)] = true
+	}
+
+	sortedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
+	}
+	sort.Strings(sortedDirs)
+
+	var pkgs []*Package
+	for _, dir := range sortedDirs {
+		if pkg := p.types.PackageByDir(dir); pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+
+	var endpoints []*Endpoint
+	for _, adapter := range adapters {
+		walker := newRouteWalker(p.logger, p.types, adapter)
+		routes := walker.discover(pkgs)
+		p.logger.Info("发现路由", zap.String("framework", adapter.Name()), zap.Int("routes", len(routes)))
+
+		for _, route := range routes {
+			if route.Unresolved != "" {
+				p.addDiagnostics(Diagnostic{
+					File:     route.File,
+					Line:     route.Line,
+					Message:  fmt.Sprintf("无法确定 %s 的接收者类型，路由 %s %s 没有写入文档", route.Unresolved, route.Method, route.Path),
+					Severity: SeverityWarning,
+				})
+				continue
+			}
+			if !supportedMethod(route.Method) {
+				p.addDiagnostics(Diagnostic{
+					File:     route.File,
+					Line:     route.Line,
+					Message:  fmt.Sprintf("OpenAPI 不支持 %s 方法，路由 %s 没有写入文档", route.Method, route.Path),
+					Severity: SeverityWarning,
+				})
+				continue
+			}
+			if endpoint := p.routeEndpoint(route); endpoint != nil {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+
+	return endpoints
+}
+
+// annotatedRoutes 去掉已由注释声明的发现路由。处理函数为匿名函数时，注释写在匿名函数上，
+// 发现的路由没有处理函数，或者处理函数是返回匿名函数的工厂函数
+func annotatedRoutes(discovered, annotated []*Endpoint) []*Endpoint {
+	handlers := make(map[string]string)
+	for _, endpoint := range annotated {
+		handlers[strings.ToUpper(endpoint.Method)+" "+pathTemplate(endpoint.Path)] = endpoint.Handler
+	}
+
+	kept := make([]*Endpoint, 0, len(discovered))
+	for _, endpoint := range discovered {
+		handler, ok := handlers[strings.ToUpper(endpoint.Method)+" "+pathTemplate(endpoint.Path)]
+		if ok && (endpoint.Handler == "" || endpoint.Handler == handler) {
+			continue
+		}
+		kept = append(kept, endpoint)
+	}
+	return kept
+}
+
+// routeAdapters 返回配置中选择的路由适配器，未配置时使用全部适配器
+func (p *Parser) routeAdapters() []RouteAdapter {
+	names := RouteAdapterNames()
+	if p.config != nil && len(p.config.Parser.Frameworks) > 0 {
+		names = p.config.Parser.Frameworks
+	}
+
+	adapters := make([]RouteAdapter, 0, len(names))
+	for _, name := range names {
+		adapter, ok := NewRouteAdapter(name)
+		if !ok {
+			p.logger.Warn("不支持的框架", zap.String("framework", name), zap.Strings("supported", RouteAdapterNames()))
+			continue
+		}
+		adapters = append(adapters, adapter)
+	}
+
+	return adapters
+}
+
+// routeEndpoint 把发现的路由转换为端点，处理函数上的注释仍然生效
+func (p *Parser) routeEndpoint(route *Route) *Endpoint {
+	endpoint := &Endpoint{
+		File:       route.File,
+		Line:       route.Line,
+		Tags:       make([]string, 0),
+		Parameters: make([]Parameter, 0),
+		Responses:  make(map[string]Response),
+	}
+
+	if handler := route.Handler; handler != nil {
+		endpoint.Handler = funcName(handler.Decl)
+		endpoint.Package = handler.Pkg.Name
+	}
+
+	if handler := route.Handler; handler != nil && handler.Decl.Doc != nil {
+		pos := p.types.Position(handler.Decl.Pos())
+		doc, diagnostics := p.comments.parseOperation(commentLines(p.types.ast.FileSet(), handler.Decl.Doc), pos.Filename, pos.Line)
+		p.addDiagnostics(diagnostics...)
+		if doc != nil {
+			if doc.Method != "" {
+				// 注释中的 @Router 优先，端点已由注释解析得到
+				return nil
+			}
+			doc.Handler, doc.Package = endpoint.Handler, endpoint.Package
+			endpoint = doc
+			p.addDiagnostics(p.resolveTypes(endpoint, importsOf(handler.File), handler.Pkg.Path)...)
+		}
+	}
+
+	endpoint.Method = route.Method
+	endpoint.Path = route.Path
+	addPathParameters(endpoint)
+
+	// OpenAPI 要求每个操作至少有一个响应
+	if len(endpoint.Responses) == 0 {
+		endpoint.Responses["default"] = Response{StatusCode: "default", Description: "Default response"}
+	}
+
+	return endpoint
+}
+
+// addPathParameters 为路径模板中未声明的参数补充 path 参数
+func addPathParameters(endpoint *Endpoint) {
+	declared := make(map[string]bool)
+	for _, param := range endpoint.Parameters {
+		if param.In == "path" {
+			declared[param.Name] = true
+		}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(endpoint.Path, -1) {
+		if declared[match[1]] {
+			continue
+		}
+		endpoint.Parameters = append(endpoint.Parameters, Parameter{
+			Name:     match[1],
+			In:       "path",
+			Type:     "string",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+}
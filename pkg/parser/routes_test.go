@@ -0,0 +1,567 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeProject 在临时目录中写入项目文件
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return root
+}
+
+// endpointsByRoute 以 "METHOD path" 为键索引端点
+func endpointsByRoute(endpoints []*Endpoint) map[string]*Endpoint {
+	byRoute := make(map[string]*Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byRoute[endpoint.Method+" "+endpoint.Path] = endpoint
+	}
+	return byRoute
+}
+
+func TestParserDiscoverGinRoutes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"example.com/shop/api"
+)
+
+func main() {
+	r := gin.Default()
+	h := &api.UserHandler{}
+
+	v1 := r.Group("/api/v1")
+	{
+		v1.GET("/users/:id", h.GetUser)
+		v1.POST("/users", h.CreateUser)
+		v1.Handle("DELETE", "/users/:id", h.DeleteUser)
+	}
+
+	api.RegisterFiles(r.Group("/files"))
+	r.Run()
+}
+`,
+		"api/user.go": `package api
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+// GetUser 获取用户
+// @Summary 获取用户
+// @Tags User
+// @Success 200 {object} User "成功"
+func (h *UserHandler) GetUser(c *gin.Context) {}
+
+// @Router /users [POST]
+// @Summary 创建用户
+func (h *UserHandler) CreateUser(c *gin.Context) {}
+
+func (h *UserHandler) DeleteUser(c *gin.Context) {}
+
+type User struct {
+	ID int
+}
+`,
+		"api/files.go": `package api
+
+import "github.com/gin-gonic/gin"
+
+// RegisterFiles 注册文件路由
+func RegisterFiles(g *gin.RouterGroup) {
+	g.GET("/*path", download)
+}
+
+func download(c *gin.Context) {}
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	byRoute := endpointsByRoute(endpoints)
+
+	// 处理函数上的注释仍然生效，路径参数自动补充
+	getUser := byRoute["GET /api/v1/users/{id}"]
+	require.NotNil(t, getUser)
+	assert.Equal(t, "获取用户", getUser.Summary)
+	assert.Equal(t, []string{"User"}, getUser.Tags)
+	assert.Equal(t, "example.com/shop/api.User", getUser.Responses["200"].Schema.Ref)
+	require.Len(t, getUser.Parameters, 1)
+	assert.Equal(t, "id", getUser.Parameters[0].Name)
+	assert.Equal(t, "path", getUser.Parameters[0].In)
+	assert.True(t, getUser.Parameters[0].Required)
+
+	// 已有 @Router 的处理函数由注释决定
+	assert.NotNil(t, byRoute["POST /users"])
+	assert.Nil(t, byRoute["POST /api/v1/users"])
+
+	// 没有注释的路由作为空操作列出
+	deleteUser := byRoute["DELETE /api/v1/users/{id}"]
+	require.NotNil(t, deleteUser)
+	assert.Empty(t, deleteUser.Summary)
+	assert.Len(t, deleteUser.Parameters, 1)
+
+	// 通过函数参数传入的路由组
+	download := byRoute["GET /files/{path}"]
+	require.NotNil(t, download)
+	assert.Equal(t, filepath.Join(root, "api", "files.go"), download.File)
+	assert.Equal(t, 7, download.Line)
+
+	assert.Len(t, endpoints, 4)
+}
+
+func TestParserDiscoverRoutesDisabled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"main.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.New()
+	r.GET("/ping", ping)
+}
+
+func ping(c *gin.Context) {}
+`,
+	})
+
+	parser := NewParser(&config.Config{}, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+}
+
+func TestParserDiscoverRoutesFromRouterFactory(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"main.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func NewRouter() *gin.Engine {
+	return gin.New()
+}
+
+func main() {
+	r := NewRouter()
+	admin := r.Group("/admin")
+	admin.Group("/users").PUT("/:id/", update)
+}
+
+func update(c *gin.Context) {}
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "PUT", endpoints[0].Method)
+	assert.Equal(t, "/admin/users/{id}/", endpoints[0].Path)
+}
+
+func TestParserDiscoverRoutesMethodReceivers(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"example.com/shop/api"
+)
+
+func main() {
+	r := gin.New()
+	u := &api.UserHandler{}
+	p := api.NewPostHandler()
+	r.GET("/users/:id", u.Get)
+	r.GET("/posts/:id", p.Get)
+	registerComments(r, &api.CommentHandler{})
+	new(api.TagHandler).Register(r.Group("/tags"))
+}
+
+func registerComments(r *gin.Engine, h *api.CommentHandler) {
+	r.GET("/comments/:id", h.Get)
+}
+`,
+		"api/handlers.go": `package api
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+// Get 获取用户
+//
+// @Summary Get a user
+// @Tags User
+func (h *UserHandler) Get(c *gin.Context) {}
+
+type PostHandler struct{}
+
+func NewPostHandler() *PostHandler { return &PostHandler{} }
+
+// @Summary Get a post
+// @Tags Post
+// @Success 200 {object} PostHandler
+func (h *PostHandler) Get(c *gin.Context) {}
+
+type CommentHandler struct{}
+
+// @Summary Get a comment
+func (h *CommentHandler) Get(c *gin.Context) {}
+
+type TagHandler struct{}
+
+func (h *TagHandler) Register(r *gin.RouterGroup) {
+	r.GET("/:id", h.Get)
+}
+
+// @Summary Get a tag
+func (h TagHandler) Get(c *gin.Context) {}
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 4)
+
+	byRoute := endpointsByRoute(endpoints)
+	user := byRoute["GET /users/{id}"]
+	require.NotNil(t, user)
+	assert.Equal(t, "UserHandler.Get", user.Handler)
+	assert.Equal(t, "Get a user", user.Summary)
+	assert.Equal(t, []string{"User"}, user.Tags)
+
+	post := byRoute["GET /posts/{id}"]
+	require.NotNil(t, post)
+	assert.Equal(t, "Get a post", post.Summary)
+	assert.Equal(t, []string{"Post"}, post.Tags)
+
+	assert.Equal(t, "Get a comment", byRoute["GET /comments/{id}"].Summary)
+	assert.Equal(t, "Get a tag", byRoute["GET /tags/{id}"].Summary)
+
+	// 没有 @Success 与 @Failure 的路由使用默认响应
+	assert.Equal(t, []string{"default"}, keysOf(user.Responses))
+	assert.Equal(t, []string{"200"}, keysOf(post.Responses))
+}
+
+func TestParserDiscoverRoutesUnresolvedReceivers(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"main.go": `package main
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"example.com/shop/api"
+)
+
+type Server struct {
+	users *api.UserHandler
+}
+
+var orders = api.NewOrderHandler()
+
+type Handler interface {
+	Delete(c *gin.Context)
+}
+
+func main() {
+	r := gin.New()
+	s := &Server{users: &api.UserHandler{}}
+	r.GET("/users/:id", s.users.Get)
+	r.GET("/orders/:id", orders.Find)
+	register(r, s.users)
+}
+
+func register(r *gin.Engine, h Handler) {
+	r.DELETE("/users/:id", h.Delete)
+}
+`,
+		"api/handlers.go": `package api
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+// @Summary Get a user
+func (h *UserHandler) Get(c *gin.Context) {}
+
+type OrderHandler struct{}
+
+func NewOrderHandler() *OrderHandler { return &OrderHandler{} }
+
+// @Summary Get an order
+func (h *OrderHandler) Find(c *gin.Context) {}
+
+type AdminHandler struct{}
+
+// @Summary Delete an admin
+// @Param id path int true "管理员 ID"
+func (h *AdminHandler) Delete(c *gin.Context) {}
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	byRoute := endpointsByRoute(endpoints)
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, "Get a user", byRoute["GET /users/{id}"].Summary)
+	assert.Equal(t, "Get an order", byRoute["GET /orders/{id}"].Summary)
+
+	// 接口类型的接收者无法确定，不使用其他类型同名方法的注释
+	assert.NotContains(t, byRoute, "DELETE /users/{id}")
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+	assert.Equal(t, 28, diagnostics[0].Line)
+	assert.Contains(t, diagnostics[0].Message, "h.Delete")
+}
+
+// keysOf 返回响应的状态码，按字典序排列
+func keysOf(responses map[string]Response) []string {
+	keys := make([]string, 0, len(responses))
+	for code := range responses {
+		keys = append(keys, code)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestParserDiscoverRoutesAnnotatedClosures(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"main.go": `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.New()
+	r.GET("/users", listUsers)
+	r.GET("/users/:id", getUser())
+	r.POST("/users", createUser)
+}
+
+// @Summary List users
+// @Router /users [GET]
+var listUsers = func(c *gin.Context) {}
+
+func getUser() gin.HandlerFunc {
+	// @Summary Get a user
+	// @Router /users/{id} [GET]
+	return func(c *gin.Context) {}
+}
+
+func createUser(c *gin.Context) {}
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 3)
+	assert.Empty(t, parser.RouteConflicts())
+
+	byRoute := endpointsByRoute(endpoints)
+	assert.Equal(t, "List users", byRoute["GET /users"].Summary)
+	assert.Equal(t, "Get a user", byRoute["GET /users/{id}"].Summary)
+	assert.Equal(t, "createUser", byRoute["POST /users"].Handler)
+}
+
+func TestJoinRoutePath(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		relative string
+		expected string
+	}{
+		{"", "/users", "/users"},
+		{"/api", "users", "/api/users"},
+		{"/api/", "/users/", "/api/users/"},
+		{"/api", "", "/api"},
+		{"", "", "/"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, joinRoutePath(tt.prefix, tt.relative))
+	}
+}
+
+func TestParserDiscoverChiRoutes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func main() {
+	r := chi.NewRouter()
+
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/", listUsers)
+		r.With(auth).Get("/{id:[0-9]+}", getUser)
+	})
+	r.Route("/orders", orderRoutes)
+
+	files := chi.NewRouter()
+	files.Get("/*", download)
+	r.Mount("/files", files)
+	r.Mount("/admin", adminRouter())
+
+	r.Get("/health", health)
+	r.Connect("/tunnel", health)
+	http.ListenAndServe(":8080", r)
+}
+
+func orderRoutes(r chi.Router) {
+	r.Post("/", createOrder)
+}
+
+func adminRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Delete("/cache", clearCache)
+	return r
+}
+
+func auth(next http.Handler) http.Handler { return next }
+func listUsers(w http.ResponseWriter, r *http.Request)  {}
+func getUser(w http.ResponseWriter, r *http.Request)    {}
+func createOrder(w http.ResponseWriter, r *http.Request) {}
+func download(w http.ResponseWriter, r *http.Request)   {}
+func clearCache(w http.ResponseWriter, r *http.Request) {}
+func health(w http.ResponseWriter, r *http.Request)     {}
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true, Frameworks: []string{"chi"}}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	byRoute := endpointsByRoute(endpoints)
+	for _, route := range []string{
+		"GET /users/",
+		"GET /users/{id}",
+		"POST /orders/",
+		"GET /files/{wildcard}",
+		"DELETE /admin/cache",
+		"GET /health",
+	} {
+		assert.Contains(t, byRoute, route)
+	}
+	assert.Len(t, endpoints, 6)
+
+	// OpenAPI 没有 CONNECT 操作，路由不写入文档并给出警告
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, "CONNECT")
+	assert.Equal(t, 24, diagnostics[0].Line)
+}
+
+func TestParserDiscoverNetHTTPAndEchoRoutes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"main.go": `package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func main() {
+	http.HandleFunc("GET /items/{id}", getItem)
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /items", http.HandlerFunc(createItem))
+
+	e := echo.New()
+	v1 := e.Group("/v1")
+	v1.PUT("/items/:id", updateItem)
+}
+
+// @Summary 获取商品
+func getItem(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary 创建商品
+func createItem(w http.ResponseWriter, r *http.Request) {}
+
+func updateItem(c echo.Context) error { return nil }
+`,
+	})
+
+	cfg := &config.Config{Parser: config.ParserConfig{DiscoverRoutes: true, Frameworks: []string{"net/http", "echo", "unknown"}}}
+	parser := NewParser(cfg, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	byRoute := endpointsByRoute(endpoints)
+	require.Contains(t, byRoute, "GET /items/{id}")
+	assert.Equal(t, "获取商品", byRoute["GET /items/{id}"].Summary)
+	require.Contains(t, byRoute, "POST /items")
+	assert.Equal(t, "创建商品", byRoute["POST /items"].Summary)
+	assert.Contains(t, byRoute, "PUT /v1/items/{id}")
+	assert.Len(t, endpoints, 3)
+}
+
+func TestNewRouteAdapter(t *testing.T) {
+	for _, name := range []string{"gin", "chi", "echo", "nethttp", "net/http", "HTTP"} {
+		adapter, ok := NewRouteAdapter(name)
+		require.True(t, ok, name)
+		assert.NotEmpty(t, adapter.Name())
+	}
+
+	_, ok := NewRouteAdapter("fiber")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"chi", "echo", "gin", "nethttp"}, RouteAdapterNames())
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// securityItemPattern 匹配安全需求中的一项，如 OAuth2[read, write]
+var securityItemPattern = regexp.MustCompile(`^([^\[\]\s]+)\s*(?:\[([^\]]*)\])?$`)
+
+// ParseSecurity 解析安全需求表达式。|| 分隔可选的需求，&& 连接需要同时满足的方案，
+// 如 "OAuth2[read, write] || ApiKeyAuth && BasicAuth"
+func ParseSecurity(expr string) []SecurityRequirement {
+	var requirements []SecurityRequirement
+
+	for _, alternative := range strings.Split(expr, "||") {
+		requirement := make(SecurityRequirement)
+
+		for _, item := range strings.Split(alternative, "&&") {
+			matches := securityItemPattern.FindStringSubmatch(strings.TrimSpace(item))
+			if matches == nil {
+				continue
+			}
+
+			scopes := make([]string, 0)
+			for _, scope := range strings.Split(matches[2], ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					scopes = append(scopes, scope)
+				}
+			}
+			requirement[matches[1]] = scopes
+		}
+
+		if len(requirement) > 0 {
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	return requirements
+}
+
+// newSecurityScheme 根据 @securityDefinitions.<kind> 标签创建安全方案，不支持的类型返回错误
+func newSecurityScheme(tag, name string) (*SecurityScheme, error) {
+	kind := strings.TrimPrefix(strings.ToLower(tag), "@securitydefinitions.")
+
+	switch kind {
+	case "basic", "bearer", "apikey":
+		return &SecurityScheme{Name: name, Type: kind}, nil
+	case "oauth2.application":
+		return &SecurityScheme{Name: name, Type: "oauth2", Flow: "application"}, nil
+	case "oauth2.implicit":
+		return &SecurityScheme{Name: name, Type: "oauth2", Flow: "implicit"}, nil
+	case "oauth2.password":
+		return &SecurityScheme{Name: name, Type: "oauth2", Flow: "password"}, nil
+	case "oauth2.accesscode":
+		return &SecurityScheme{Name: name, Type: "oauth2", Flow: "accessCode"}, nil
+	}
+
+	return nil, fmt.Errorf("不支持的安全方案类型 %q，应为 basic、bearer、apikey、oauth2.application、oauth2.implicit、oauth2.password 或 oauth2.accessCode", kind)
+}
+
+// applySecurityAttribute 把安全方案定义后的属性标签应用到方案上，返回标签是否被识别
+func applySecurityAttribute(scheme *SecurityScheme, tag, value string) bool {
+	lower := strings.ToLower(tag)
+
+	switch lower {
+	case "@in":
+		scheme.In = value
+	case "@name":
+		scheme.ParamName = value
+	case "@description":
+		scheme.Description = value
+	case "@bearerformat":
+		scheme.BearerFormat = value
+	case "@tokenurl":
+		scheme.TokenURL = value
+	case "@authorizationurl":
+		scheme.AuthorizationURL = value
+	case "@refreshurl":
+		scheme.RefreshURL = value
+	default:
+		if !strings.HasPrefix(lower, "@scope.") {
+			return false
+		}
+		if scheme.Scopes == nil {
+			scheme.Scopes = make(map[string]string)
+		}
+		scheme.Scopes[tag[len("@scope."):]] = value
+	}
+
+	return true
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseSecurity(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected []SecurityRequirement
+	}{
+		{"single", "BearerAuth", []SecurityRequirement{{"BearerAuth": {}}}},
+		{"scopes", "OAuth2[read, write]", []SecurityRequirement{{"OAuth2": {"read", "write"}}}},
+		{"or", "BearerAuth || ApiKeyAuth", []SecurityRequirement{{"BearerAuth": {}}, {"ApiKeyAuth": {}}}},
+		{"and", "ApiKeyAuth && BasicAuth", []SecurityRequirement{{"ApiKeyAuth": {}, "BasicAuth": {}}}},
+		{
+			"or of and",
+			"OAuth2[admin] || ApiKeyAuth && BasicAuth",
+			[]SecurityRequirement{{"OAuth2": {"admin"}}, {"ApiKeyAuth": {}, "BasicAuth": {}}},
+		},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseSecurity(tt.expr))
+		})
+	}
+}
+
+func TestCommentParserParseSecurityDefinitions(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	info := cp.ParseGeneralInfo([]string{
+		"// @title 商城 API",
+		"// @securityDefinitions.basic BasicAuth",
+		"// @securityDefinitions.apikey ApiKeyAuth",
+		"// @in header",
+		"// @name X-API-Key",
+		"// @description 接口密钥",
+		"// @securityDefinitions.bearer BearerAuth",
+		"// @bearerFormat JWT",
+		"// @securityDefinitions.oauth2.accessCode OAuth2",
+		"// @authorizationUrl https://example.com/oauth/authorize",
+		"// @tokenUrl https://example.com/oauth/token",
+		"// @scope.read 读取数据",
+		"// @scope.write 写入数据",
+		"// @Security BearerAuth || ApiKeyAuth",
+	})
+	require.NotNil(t, info)
+	require.Len(t, info.SecuritySchemes, 4)
+
+	assert.Equal(t, SecurityScheme{Name: "BasicAuth", Type: "basic"}, info.SecuritySchemes[0])
+	assert.Equal(t, SecurityScheme{
+		Name:        "ApiKeyAuth",
+		Type:        "apikey",
+		In:          "header",
+		ParamName:   "X-API-Key",
+		Description: "接口密钥",
+	}, info.SecuritySchemes[1])
+	assert.Equal(t, "JWT", info.SecuritySchemes[2].BearerFormat)
+
+	oauth := info.SecuritySchemes[3]
+	assert.Equal(t, "oauth2", oauth.Type)
+	assert.Equal(t, "accessCode", oauth.Flow)
+	assert.Equal(t, "https://example.com/oauth/token", oauth.TokenURL)
+	assert.Equal(t, map[string]string{"read": "读取数据", "write": "写入数据"}, oauth.Scopes)
+
+	assert.Equal(t, []SecurityRequirement{{"BearerAuth": {}}, {"ApiKeyAuth": {}}}, info.Security)
+
+	// 只有安全方案定义的注释块也是通用信息
+	assert.NotNil(t, cp.ParseGeneralInfo([]string{"// @securityDefinitions.basic BasicAuth"}))
+}
+
+func TestParserWarnsUnknownSecurityDefinition(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"main.go": `package main
+
+// @title 商城 API
+// @securityDefinitions.apikeyy ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description 接口密钥
+// @securityDefinitions.basic BasicAuth
+func main() {}
+`,
+	})
+
+	parser := NewParser(&config.Config{}, logger)
+	_, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	// 拼错的方案被丢弃，其属性标签不会写入其他字段
+	info := parser.GeneralInfo()
+	require.NotNil(t, info)
+	assert.Equal(t, []SecurityScheme{{Name: "BasicAuth", Type: "basic"}}, info.SecuritySchemes)
+	assert.Empty(t, info.Description)
+
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, filepath.Join(root, "main.go"), diagnostics[0].File)
+	assert.Equal(t, 4, diagnostics[0].Line)
+	assert.Equal(t, "@securityDefinitions.apikeyy", diagnostics[0].Tag)
+	assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, "apikeyy")
+}
+
+func TestCommentParserParseEndpointSecurity(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := NewCommentParser(logger)
+
+	endpoint := cp.ParseEndpoint([]string{
+		"// @Router /users [GET]",
+		"// @Security OAuth2[read]",
+		"// @Security ApiKeyAuth && BasicAuth",
+	}, "test.go", 1)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, []SecurityRequirement{
+		{"OAuth2": {"read"}},
+		{"ApiKeyAuth": {}, "BasicAuth": {}},
+	}, endpoint.Security)
+}
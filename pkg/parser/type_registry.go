@@ -0,0 +1,590 @@
+package parser
+
+import (
+	"bufio"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// TypeDecl 代表一个命名类型声明
+type TypeDecl struct {
+	Name    string
+	PkgPath string // 所在包的导入路径
+	PkgName string
+	File    *ast.File // 声明所在文件，用于解析字段类型中的包限定符
+	Spec    *ast.TypeSpec
+	Doc     string
+	Enums   []EnumValue // 以该类型声明的常量，按声明顺序排列
+}
+
+// ID 返回类型的全限定标识，如 github.com/org/app/model.User
+func (d *TypeDecl) ID() string {
+	return d.PkgPath + "." + d.Name
+}
+
+// FuncInfo 代表一个函数或方法声明
+type FuncInfo struct {
+	Decl *ast.FuncDecl
+	File *ast.File
+	Pkg  *Package
+}
+
+// Package 代表一个已加载的 Go 包
+type Package struct {
+	Path    string // 导入路径
+	Name    string
+	Dir     string
+	Files   []*ast.File
+	Types   map[string]*TypeDecl
+	Funcs   map[string]*FuncInfo   // 包级函数
+	Methods map[string][]*FuncInfo // 按方法名索引，不区分接收者类型
+}
+
+// TypeRegistry 按需加载模块内的包，并把注释中的类型名解析为类型声明
+type TypeRegistry struct {
+	logger     *zap.Logger
+	ast        *ASTParser
+	moduleRoot string
+	modulePath string
+	packages   map[string]*Package // 以目录为键
+	build      *build.Context      // 加载包时用于判断文件的构建约束
+	mu         sync.Mutex
+
+	// 模块内包名到目录的索引，第一次按包名查找时建立
+	byName     map[string][]string
+	byNameOnce sync.Once
+
+	// 已解析文件中声明的类型，以导入路径为键，解析类型引用时优先使用，不必加载整个包
+	declared map[string]*declaredTypes
+}
+
+// declaredTypes 代表一个包中已登记的类型名
+type declaredTypes struct {
+	name  string
+	types map[string]bool
+}
+
+// NewTypeRegistry 创建一个新的类型注册表
+func NewTypeRegistry(logger *zap.Logger) *TypeRegistry {
+	return &TypeRegistry{
+		logger:   logger,
+		ast:      NewASTParser(logger),
+		packages: make(map[string]*Package),
+		build:    &build.Default,
+		declared: make(map[string]*declaredTypes),
+	}
+}
+
+// AddFileTypes 登记文件中声明的类型，测试文件与构建约束不满足的文件不会被加载，因此不登记
+func (r *TypeRegistry) AddFileTypes(filePath string, types *FileTypes) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dir, name := filepath.Split(filePath)
+	if strings.HasSuffix(name, "_test.go") {
+		return
+	}
+	if match, err := r.build.MatchFile(dir, name); err != nil || !match {
+		return
+	}
+
+	declared := r.declared[types.PkgPath]
+	if declared == nil {
+		declared = &declaredTypes{name: types.PkgName, types: make(map[string]bool)}
+		r.declared[types.PkgPath] = declared
+	}
+	for _, typeName := range types.Types {
+		declared.types[typeName] = true
+	}
+}
+
+// SetBuildContext 设置加载包时使用的构建上下文，构建约束不满足的文件不会被加载
+func (r *TypeRegistry) SetBuildContext(ctx *build.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.build = ctx
+}
+
+// LoadModule 从项目路径向上查找 go.mod，确定模块根目录和模块路径
+func (r *TypeRegistry) LoadModule(projectPath string) error {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		modulePath, err := readModulePath(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			r.moduleRoot = dir
+			r.modulePath = modulePath
+			r.logger.Debug("找到 Go 模块", zap.String("root", dir), zap.String("module", modulePath))
+			return nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// 没有 go.mod 时以项目路径为根，包按目录标识
+			r.moduleRoot, _ = filepath.Abs(projectPath)
+			r.modulePath = ""
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// readModulePath 读取 go.mod 中声明的模块路径
+func readModulePath(goModPath string) (string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module") {
+			path := strings.TrimSpace(strings.TrimPrefix(line, "module"))
+			if unquoted, err := strconv.Unquote(path); err == nil {
+				path = unquoted
+			}
+			return path, nil
+		}
+	}
+
+	return "", os.ErrNotExist
+}
+
+// ImportPath 返回目录对应的导入路径；不在模块内的目录以其绝对路径标识
+func (r *TypeRegistry) ImportPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+
+	if r.modulePath != "" {
+		if rel, err := filepath.Rel(r.moduleRoot, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			if rel == "." {
+				return r.modulePath
+			}
+			return r.modulePath + "/" + filepath.ToSlash(rel)
+		}
+	}
+
+	return filepath.ToSlash(abs)
+}
+
+// dirForImport 返回导入路径对应的目录，无法映射到本地源码时返回空字符串
+func (r *TypeRegistry) dirForImport(importPath string) string {
+	if r.modulePath != "" {
+		if importPath == r.modulePath {
+			return r.moduleRoot
+		}
+		if strings.HasPrefix(importPath, r.modulePath+"/") {
+			return filepath.Join(r.moduleRoot, filepath.FromSlash(strings.TrimPrefix(importPath, r.modulePath+"/")))
+		}
+	}
+
+	if filepath.IsAbs(filepath.FromSlash(importPath)) {
+		return filepath.FromSlash(importPath)
+	}
+
+	return ""
+}
+
+// PackageByPath 按导入路径加载包
+func (r *TypeRegistry) PackageByPath(importPath string) *Package {
+	dir := r.dirForImport(importPath)
+	if dir == "" {
+		return nil
+	}
+	return r.PackageByDir(dir)
+}
+
+// PackageByDir 加载目录中的包，结果会被缓存
+func (r *TypeRegistry) PackageByDir(dir string) *Package {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pkg, ok := r.packages[abs]; ok {
+		return pkg
+	}
+
+	pkg := r.loadPackage(abs)
+	r.packages[abs] = pkg
+	return pkg
+}
+
+// loadPackage 解析目录中的非测试 Go 文件并收集类型声明
+func (r *TypeRegistry) loadPackage(dir string) *Package {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	pkg := &Package{
+		Path:    r.ImportPath(dir),
+		Dir:     dir,
+		Types:   make(map[string]*TypeDecl),
+		Funcs:   make(map[string]*FuncInfo),
+		Methods: make(map[string][]*FuncInfo),
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := r.build.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+
+		file, err := r.ast.ParseFile(filepath.Join(dir, name))
+		if err != nil {
+			r.logger.Debug("加载类型失败", zap.String("file", name), zap.Error(err))
+			continue
+		}
+
+		if pkg.Name == "" {
+			pkg.Name = file.Name.Name
+		}
+		pkg.Files = append(pkg.Files, file)
+		r.collectTypes(pkg, file)
+		r.collectFuncs(pkg, file)
+	}
+
+	if len(pkg.Files) == 0 {
+		return nil
+	}
+
+	// 常量可能与类型声明在不同文件中，收集完所有类型后再处理
+	r.collectEnums(pkg)
+
+	return pkg
+}
+
+// collectTypes 收集文件中的类型声明
+func (r *TypeRegistry) collectTypes(pkg *Package, file *ast.File) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+
+			pkg.Types[typeSpec.Name.Name] = &TypeDecl{
+				Name:    typeSpec.Name.Name,
+				PkgPath: pkg.Path,
+				PkgName: pkg.Name,
+				File:    file,
+				Spec:    typeSpec,
+				Doc:     strings.TrimSpace(doc.Text()),
+			}
+		}
+	}
+}
+
+// collectFuncs 收集文件中的函数与方法声明
+func (r *TypeRegistry) collectFuncs(pkg *Package, file *ast.File) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		info := &FuncInfo{Decl: funcDecl, File: file, Pkg: pkg}
+		if funcDecl.Recv == nil {
+			pkg.Funcs[funcDecl.Name.Name] = info
+		} else {
+			pkg.Methods[funcDecl.Name.Name] = append(pkg.Methods[funcDecl.Name.Name], info)
+		}
+	}
+}
+
+// Position 返回已加载文件中某个位置的文件名与行号
+func (r *TypeRegistry) Position(pos token.Pos) token.Position {
+	return r.ast.FileSet().Position(pos)
+}
+
+// Lookup 根据全限定标识查找类型声明，泛型实例化返回其泛型类型声明
+func (r *TypeRegistry) Lookup(id string) *TypeDecl {
+	id, _ = SplitTypeArgs(id)
+	idx := strings.LastIndex(id, ".")
+	if idx <= 0 {
+		return nil
+	}
+
+	pkg := r.PackageByPath(id[:idx])
+	if pkg == nil {
+		return nil
+	}
+
+	return pkg.Types[id[idx+1:]]
+}
+
+// ResolveName 在文件上下文中解析类型名，支持 User、*User、model.User 形式，
+// 泛型实例化如 Result[User] 返回其泛型类型声明
+func (r *TypeRegistry) ResolveName(name string, file *ast.File, pkgPath string) *TypeDecl {
+	id, ok := r.resolveID(name, importsOf(file), pkgPath)
+	if !ok {
+		return nil
+	}
+	return r.Lookup(id)
+}
+
+// resolveID 在导入上下文中把类型名解析为全限定标识，泛型实例化返回其泛型类型的标识。
+// 已登记的类型直接使用登记的信息，其余的按需加载包
+func (r *TypeRegistry) resolveID(name string, imports []Import, pkgPath string) (string, bool) {
+	name, _ = SplitTypeArgs(strings.TrimPrefix(strings.TrimSpace(name), "*"))
+
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return r.declares(pkgPath, name)
+	}
+
+	qualifier, typeName := name[:idx], name[idx+1:]
+
+	// 完整导入路径形式，如 github.com/org/app/model.User
+	if strings.Contains(qualifier, "/") {
+		return r.declares(qualifier, typeName)
+	}
+
+	// 文件中导入的包
+	for _, imp := range imports {
+		local := imp.Name
+		if local == "" {
+			local = r.packageName(imp.Path)
+		}
+		if local == qualifier {
+			return r.declares(imp.Path, typeName)
+		}
+	}
+
+	// 未导入的包按包名在模块中查找
+	if id, ok := r.declaredByName(qualifier, typeName); ok {
+		return id, true
+	}
+	if decl := r.findByPackageName(qualifier, typeName); decl != nil {
+		return decl.ID(), true
+	}
+	return "", false
+}
+
+// declares 判断包中是否声明了类型，返回类型的全限定标识
+func (r *TypeRegistry) declares(pkgPath, typeName string) (string, bool) {
+	id := pkgPath + "." + typeName
+
+	r.mu.Lock()
+	declared := r.declared[pkgPath]
+	found := declared != nil && declared.types[typeName]
+	r.mu.Unlock()
+	if found {
+		return id, true
+	}
+
+	if pkg := r.PackageByPath(pkgPath); pkg != nil && pkg.Types[typeName] != nil {
+		return id, true
+	}
+	return "", false
+}
+
+// declaredByName 在已登记的包中按包名查找类型，包名相同时按导入路径的顺序取第一个
+func (r *TypeRegistry) declaredByName(pkgName, typeName string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paths := make([]string, 0, len(r.declared))
+	for path, declared := range r.declared {
+		if declared.name == pkgName && declared.types[typeName] {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return "", false
+	}
+
+	sort.Strings(paths)
+	return paths[0] + "." + typeName, true
+}
+
+// packageName 返回导入路径对应的包名，模块外的包返回空字符串
+func (r *TypeRegistry) packageName(importPath string) string {
+	r.mu.Lock()
+	declared := r.declared[importPath]
+	r.mu.Unlock()
+	if declared != nil {
+		return declared.name
+	}
+
+	if pkg := r.PackageByPath(importPath); pkg != nil {
+		return pkg.Name
+	}
+	return ""
+}
+
+// importsOf 返回文件中的导入
+func importsOf(file *ast.File) []Import {
+	if file == nil {
+		return nil
+	}
+
+	imports := make([]Import, 0, len(file.Imports))
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		var name string
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports = append(imports, Import{Name: name, Path: path})
+	}
+	return imports
+}
+
+// External 判断类型名是否引用模块外的包，如文件导入的 github.com/gin-gonic/gin 中的 gin.H。
+// 模块外的包没有本地源码，无法解析其中的类型
+func (r *TypeRegistry) External(name string, imports []Import) bool {
+	name, _ = SplitTypeArgs(strings.TrimLeft(strings.TrimSpace(name), "*[]"))
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return false
+	}
+
+	qualifier := name[:idx]
+	if strings.Contains(qualifier, "/") {
+		return r.dirForImport(qualifier) == ""
+	}
+
+	for _, imp := range imports {
+		local := imp.Name
+		if local == "" {
+			if local = r.packageName(imp.Path); local == "" {
+				local = imp.Path[strings.LastIndex(imp.Path, "/")+1:]
+			}
+		}
+		if local == qualifier {
+			return r.dirForImport(imp.Path) == ""
+		}
+	}
+	return false
+}
+
+// findByPackageName 在模块内按包名查找类型，只加载包名匹配的包
+func (r *TypeRegistry) findByPackageName(pkgName, typeName string) *TypeDecl {
+	r.byNameOnce.Do(r.indexPackageNames)
+
+	for _, dir := range r.byName[pkgName] {
+		if pkg := r.PackageByDir(dir); pkg != nil {
+			if decl, ok := pkg.Types[typeName]; ok {
+				return decl
+			}
+		}
+	}
+	return nil
+}
+
+// indexPackageNames 建立模块内包名到目录的索引，每个目录只读取一个文件的包声明
+func (r *TypeRegistry) indexPackageNames() {
+	r.byName = make(map[string][]string)
+
+	fset := token.NewFileSet()
+	for _, dir := range r.moduleDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			if match, err := r.build.MatchFile(dir, name); err != nil || !match {
+				continue
+			}
+
+			file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+			if err != nil {
+				continue
+			}
+			r.byName[file.Name.Name] = append(r.byName[file.Name.Name], dir)
+			break
+		}
+	}
+}
+
+// loadedPackages 返回已加载的包，按目录排序
+func (r *TypeRegistry) loadedPackages() []*Package {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dirs := make([]string, 0, len(r.packages))
+	for dir, pkg := range r.packages {
+		if pkg != nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+
+	pkgs := make([]*Package, 0, len(dirs))
+	for _, dir := range dirs {
+		pkgs = append(pkgs, r.packages[dir])
+	}
+	return pkgs
+}
+
+// moduleDirs 返回模块内所有可能包含源码的目录，按路径排序
+func (r *TypeRegistry) moduleDirs() []string {
+	if r.moduleRoot == "" {
+		return nil
+	}
+
+	var dirs []string
+	filepath.Walk(r.moduleRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		name := info.Name()
+		if path != r.moduleRoot && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+
+		dirs = append(dirs, path)
+		return nil
+	})
+
+	sort.Strings(dirs)
+	return dirs
+}
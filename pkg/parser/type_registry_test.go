@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeModule 在临时目录中创建一个包含 model 与 api 两个包的模块
+func writeModule(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/user.go": `package model
+
+// User 用户模型
+type User struct {
+	ID   int
+	Name string
+}
+`,
+		"api/user.go": `package api
+
+import m "example.com/shop/model"
+
+// @Router /users/{id} [GET]
+// @Param body body m.User true "用户信息"
+// @Success 200 {object} m.User "成功"
+// @Success 201 {array} model.User "成功"
+// @Failure 400 {object} ErrorResponse "请求错误"
+// @Failure 500 {object} Missing "服务器错误"
+func GetUser() {}
+
+type ErrorResponse struct {
+	Code int
+}
+
+var _ m.User
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return root
+}
+
+func TestTypeRegistryLoadModule(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeModule(t)
+
+	types := NewTypeRegistry(logger)
+	require.NoError(t, types.LoadModule(filepath.Join(root, "api")))
+
+	assert.Equal(t, "example.com/shop", types.ImportPath(root))
+	assert.Equal(t, "example.com/shop/model", types.ImportPath(filepath.Join(root, "model")))
+}
+
+func TestTypeRegistryResolveName(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeModule(t)
+
+	types := NewTypeRegistry(logger)
+	require.NoError(t, types.LoadModule(root))
+
+	apiPkg := types.PackageByDir(filepath.Join(root, "api"))
+	require.NotNil(t, apiPkg)
+	file := apiPkg.Files[0]
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "ErrorResponse", want: "example.com/shop/api.ErrorResponse"},
+		{name: "*ErrorResponse", want: "example.com/shop/api.ErrorResponse"},
+		{name: "m.User", want: "example.com/shop/model.User"},
+		{name: "model.User", want: "example.com/shop/model.User"},
+		{name: "example.com/shop/model.User", want: "example.com/shop/model.User"},
+		{name: "Missing", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decl := types.ResolveName(tt.name, file, apiPkg.Path)
+			if tt.want == "" {
+				assert.Nil(t, decl)
+				return
+			}
+			require.NotNil(t, decl)
+			assert.Equal(t, tt.want, decl.ID())
+		})
+	}
+}
+
+func TestTypeRegistryLookup(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeModule(t)
+
+	types := NewTypeRegistry(logger)
+	require.NoError(t, types.LoadModule(root))
+
+	decl := types.Lookup("example.com/shop/model.User")
+	require.NotNil(t, decl)
+	assert.Equal(t, "User", decl.Name)
+	assert.Equal(t, "model", decl.PkgName)
+	assert.Equal(t, "User 用户模型", decl.Doc)
+
+	assert.Nil(t, types.Lookup("example.com/shop/model.Missing"))
+	assert.Nil(t, types.Lookup("fmt.Stringer"))
+}
+
+func TestParserResolvesEndpointTypes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeModule(t)
+	parser := NewParser(&config.Config{}, logger)
+
+	endpoints, err := parser.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	endpoint := endpoints[0]
+	assert.Equal(t, "example.com/shop/model.User", endpoint.Parameters[0].Schema.Ref)
+	assert.Equal(t, "example.com/shop/model.User", endpoint.Responses["200"].Schema.Ref)
+	assert.Equal(t, "example.com/shop/model.User", endpoint.Responses["201"].Schema.Items.Ref)
+	assert.Equal(t, "example.com/shop/api.ErrorResponse", endpoint.Responses["400"].Schema.Ref)
+	assert.Equal(t, "Missing", endpoint.Responses["500"].Schema.Ref)
+
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, filepath.Join(root, "api", "user.go"), diagnostics[0].File)
+	assert.Equal(t, 11, diagnostics[0].Line)
+	assert.Equal(t, "@Failure", diagnostics[0].Tag)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, `"Missing"`)
+}
+
+func TestParserUnresolvedTypeDiagnostics(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeProject(t, map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"api/user.go": `package api
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /users [GET]
+// @Param since query time.Time false "起始时间"
+// @Success 200 {object} gin.H "成功"
+// @Success 201 {object} map[string]int "成功"
+// @Failure 400 {object} Usr "请求错误"
+func ListUsers() {}
+
+var _ = time.Now
+var _ gin.H
+`,
+	})
+
+	parser := NewParser(&config.Config{}, logger)
+	_, err := parser.ParseProject(root)
+	require.NoError(t, err)
+
+	diagnostics := parser.Diagnostics()
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "@Success", diagnostics[0].Tag)
+	assert.Equal(t, SeverityWarning, diagnostics[0].Severity)
+	assert.Contains(t, diagnostics[0].Message, `"gin.H"`)
+	assert.Equal(t, "@Failure", diagnostics[1].Tag)
+	assert.Equal(t, SeverityError, diagnostics[1].Severity)
+	assert.Contains(t, diagnostics[1].Message, `"Usr"`)
+}
+
+func TestTypeRegistryFindByPackageName(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	root := writeModule(t)
+
+	types := NewTypeRegistry(logger)
+	require.NoError(t, types.LoadModule(root))
+
+	// 只加载包名匹配的包
+	assert.Nil(t, types.ResolveName("gin.H", nil, "example.com/shop/api"))
+	assert.Empty(t, types.loadedPackages())
+
+	decl := types.ResolveName("model.User", nil, "example.com/shop/api")
+	require.NotNil(t, decl)
+	assert.Equal(t, "example.com/shop/model.User", decl.ID())
+	require.Len(t, types.loadedPackages(), 1)
+	assert.Equal(t, "model", types.loadedPackages()[0].Name)
+}
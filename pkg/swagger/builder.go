@@ -3,6 +3,9 @@ package swagger
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/neglet30/swag-gen/pkg/parser"
 	"gopkg.in/yaml.v3"
@@ -10,9 +13,22 @@ import (
 
 // Builder is responsible for building Swagger/OpenAPI documentation.
 type Builder struct {
-	doc *SwaggerDoc
+	doc     *SwaggerDoc
+	schemas *SchemaBuilder
+	synced  map[string]bool // component names copied from schemas
+	accept  []string        // media types for request bodies without @Accept
+	produce []string        // media types for responses without @Produce
+
+	tagOrder  TagOrder
+	tagSeq    map[string]int // order in which tags were first added
+	tagDecl   map[string]int // order in which tags were declared with @tag.name
+	tagGroups []TagGroup
 }
 
+// defaultMediaType is used when neither the endpoint nor the project
+// declares media types.
+const defaultMediaType = "application/json"
+
 // NewBuilder creates a new Swagger builder with the given title, version, and description.
 func NewBuilder(title, version, description string) *Builder {
 	return &Builder{
@@ -29,9 +45,39 @@ func NewBuilder(title, version, description string) *Builder {
 			},
 			Tags: make([]Tag, 0),
 		},
+		schemas:  NewSchemaBuilder(),
+		synced:   make(map[string]bool),
+		accept:   []string{defaultMediaType},
+		produce:  []string{defaultMediaType},
+		tagOrder: TagOrderDeclared,
+		tagSeq:   make(map[string]int),
+		tagDecl:  make(map[string]int),
+	}
+}
+
+// SetDefaultMediaTypes sets the media types used by operations without
+// @Accept or @Produce annotations. Empty lists keep the current defaults.
+func (b *Builder) SetDefaultMediaTypes(accept, produce []string) {
+	if len(accept) > 0 {
+		b.accept = accept
+	}
+	if len(produce) > 0 {
+		b.produce = produce
 	}
 }
 
+// SetTypeRegistry sets the registry used to resolve Go types referenced by
+// endpoints into component schemas.
+func (b *Builder) SetTypeRegistry(types *parser.TypeRegistry) {
+	b.schemas.SetTypeRegistry(types)
+}
+
+// SetEmbeddedAllOf renders embedded structs as allOf compositions instead of
+// flattening their fields into the parent schema.
+func (b *Builder) SetEmbeddedAllOf(enabled bool) {
+	b.schemas.SetEmbeddedAllOf(enabled)
+}
+
 // AddEndpoint adds an endpoint to the Swagger documentation.
 func (b *Builder) AddEndpoint(endpoint *parser.Endpoint) error {
 	if endpoint == nil {
@@ -46,44 +92,102 @@ func (b *Builder) AddEndpoint(endpoint *parser.Endpoint) error {
 		return fmt.Errorf("endpoint method cannot be empty")
 	}
 
+	if !slices.Contains(httpMethods, endpoint.Method) {
+		return fmt.Errorf("unsupported HTTP method: %s", endpoint.Method)
+	}
+
 	// Get or create path item
 	pathItem, exists := b.doc.Paths[endpoint.Path]
 	if !exists {
 		pathItem = PathItem{}
 	}
 
+	// Never overwrite an operation added earlier; conflicting routes are
+	// resolved by the parser according to the configured policy.
+	if pathItem.operation(endpoint.Method) != nil {
+		return fmt.Errorf("operation %s %s already exists", endpoint.Method, endpoint.Path)
+	}
+
 	// Create operation
 	operation := &Operation{
+		OperationID: endpoint.OperationID,
 		Summary:     endpoint.Summary,
 		Description: endpoint.Description,
 		Tags:        endpoint.Tags,
 		Responses:   make(map[string]Response),
 		Deprecated:  endpoint.Deprecated,
+		Security:    convertSecurity(endpoint.Security),
 	}
 
-	// Add parameters
-	if len(endpoint.Parameters) > 0 {
-		operation.Parameters = make([]Parameter, 0, len(endpoint.Parameters))
-		for _, param := range endpoint.Parameters {
-			operation.Parameters = append(operation.Parameters, Parameter{
+	// Add parameters. Body and formData parameters become the request body,
+	// which OpenAPI 3 keeps apart from the other parameter locations.
+	var formParams []Parameter
+	for _, param := range endpoint.Parameters {
+		if param.In == "body" {
+			if operation.RequestBody == nil {
+				operation.RequestBody = &RequestBody{
+					Description: param.Description,
+					Required:    param.Required,
+					Content:     mediaContent(orDefault(endpoint.Accept, b.accept), b.parameterSchema(param)),
+				}
+			}
+			continue
+		}
+
+		params := b.structParameters(param)
+		if params == nil {
+			p := Parameter{
 				Name:        param.Name,
 				In:          param.In,
 				Description: param.Description,
 				Required:    param.Required,
-				Schema: &Schema{
-					Type: param.Type,
-				},
-			})
+				Schema:      b.parameterSchema(param),
+			}
+			// Swagger 2 serializes arrays without a collectionFormat as csv.
+			format := param.CollectionFormat
+			if format == "" && p.Schema != nil && p.Schema.Type == "array" {
+				format = "csv"
+			}
+			p.Style, p.Explode = collectionStyle(param.In, format)
+			params = []Parameter{p}
 		}
+
+		if param.In == "formData" {
+			formParams = append(formParams, params...)
+			continue
+		}
+		operation.Parameters = append(operation.Parameters, params...)
 	}
 
-	// Add responses
-	for statusCode, response := range endpoint.Responses {
-		operation.Responses[statusCode] = Response{
+	if len(formParams) > 0 {
+		operation.RequestBody = formRequestBody(operation.RequestBody, formParams, endpoint.Accept)
+	}
+
+	// Add responses in status order, so schemas are built in the same order
+	// on every run.
+	for _, statusCode := range slices.Sorted(maps.Keys(endpoint.Responses)) {
+		response := endpoint.Responses[statusCode]
+		resp := Response{
 			Description: response.Description,
 		}
+		if response.Schema != nil {
+			resp.Content = mediaContent(orDefault(endpoint.Produce, b.produce), b.convertSchema(response.Schema))
+		}
+		if len(response.Headers) > 0 {
+			resp.Headers = make(map[string]Header, len(response.Headers))
+			for _, name := range slices.Sorted(maps.Keys(response.Headers)) {
+				header := response.Headers[name]
+				resp.Headers[name] = Header{
+					Description: header.Description,
+					Schema:      b.convertSchema(header.Schema),
+				}
+			}
+		}
+		operation.Responses[statusCode] = resp
 	}
 
+	b.syncComponents()
+
 	// Set operation on path item based on method
 	method := endpoint.Method
 	switch method {
@@ -113,13 +217,279 @@ func (b *Builder) AddEndpoint(endpoint *parser.Endpoint) error {
 	// Add tags if not already present
 	for _, tag := range endpoint.Tags {
 		if !b.hasTag(tag) {
-			b.doc.Tags = append(b.doc.Tags, Tag{Name: tag})
+			b.addTag(Tag{Name: tag}, false)
 		}
 	}
 
 	return nil
 }
 
+// Media types that carry form fields.
+const (
+	multipartFormData = "multipart/form-data"
+	urlEncodedForm    = "application/x-www-form-urlencoded"
+)
+
+// formRequestBody combines formData parameters into an object schema and
+// adds it to the request body under the form media types.
+func formRequestBody(body *RequestBody, params []Parameter, accept []string) *RequestBody {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema, len(params)),
+	}
+
+	hasFile := false
+	required := false
+	for _, param := range params {
+		prop := param.Schema
+		if param.Description != "" && prop.Ref == "" {
+			described := *prop
+			described.Description = param.Description
+			prop = &described
+		}
+
+		schema.Properties[param.Name] = prop
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+			required = true
+		}
+		if isBinary(prop) {
+			hasFile = true
+		}
+	}
+
+	if body == nil {
+		body = &RequestBody{Content: make(map[string]MediaType)}
+	}
+	body.Required = body.Required || required
+
+	for _, mediaType := range formMediaTypes(accept, hasFile) {
+		body.Content[mediaType] = MediaType{
+			Schema:   schema,
+			Encoding: formEncoding(mediaType, schema),
+		}
+	}
+
+	return body
+}
+
+// formMediaTypes returns the form media types accepted by an operation.
+// Without a form type in @Accept, files require multipart/form-data and
+// plain fields default to application/x-www-form-urlencoded, which cannot
+// carry files.
+func formMediaTypes(accept []string, hasFile bool) []string {
+	var mediaTypes []string
+	for _, mediaType := range accept {
+		switch mediaType {
+		case multipartFormData:
+			mediaTypes = append(mediaTypes, mediaType)
+		case urlEncodedForm:
+			if !hasFile {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+
+	if len(mediaTypes) > 0 {
+		return mediaTypes
+	}
+	if hasFile {
+		return []string{multipartFormData}
+	}
+	return []string{urlEncodedForm}
+}
+
+// formEncoding describes how form fields are serialized: files are sent as
+// octet streams in multipart bodies, and arrays as comma-separated values in
+// URL-encoded bodies, matching the default csv collection format.
+func formEncoding(mediaType string, schema *Schema) map[string]Encoding {
+	encoding := make(map[string]Encoding)
+
+	for name, prop := range schema.Properties {
+		switch {
+		case mediaType == multipartFormData && isBinary(prop):
+			encoding[name] = Encoding{ContentType: "application/octet-stream"}
+		case mediaType == urlEncodedForm && prop.Type == "array":
+			explode := false
+			encoding[name] = Encoding{Style: "form", Explode: &explode}
+		}
+	}
+
+	if len(encoding) == 0 {
+		return nil
+	}
+	return encoding
+}
+
+// isBinary reports whether a schema describes a file or a list of files.
+func isBinary(schema *Schema) bool {
+	if schema.Type == "array" && schema.Items != nil {
+		return isBinary(schema.Items)
+	}
+	return schema.Type == "string" && schema.Format == "binary"
+}
+
+// mediaContent builds a content map that uses the same schema for every
+// media type.
+func mediaContent(mediaTypes []string, schema *Schema) map[string]MediaType {
+	content := make(map[string]MediaType, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = MediaType{Schema: schema}
+	}
+	return content
+}
+
+// orDefault returns values, or defaults when values is empty.
+func orDefault(values, defaults []string) []string {
+	if len(values) > 0 {
+		return values
+	}
+	return defaults
+}
+
+// parameterSchema returns the schema of a parameter, falling back to its
+// declared type when the parser did not attach one.
+func (b *Builder) parameterSchema(param parser.Parameter) *Schema {
+	if param.Schema != nil {
+		return b.convertSchema(param.Schema)
+	}
+	return &Schema{Type: param.Type}
+}
+
+// collectionStyle maps a Swagger 2 collectionFormat onto the OpenAPI 3 style
+// and explode of a parameter. Formats without an equivalent for the location,
+// such as tsv, keep the default serialization.
+func collectionStyle(in, format string) (string, *bool) {
+	explode := format == "multi"
+
+	switch {
+	case format == "csv" && (in == "query" || in == "cookie"):
+		return "form", &explode
+	case format == "csv":
+		return "simple", nil
+	case format == "multi" && in == "query":
+		return "form", &explode
+	case format == "ssv" && in == "query":
+		return "spaceDelimited", &explode
+	case format == "pipes" && in == "query":
+		return "pipeDelimited", &explode
+	default:
+		return "", nil
+	}
+}
+
+// structParameters expands a query or form parameter bound to a struct into
+// one parameter per field, named after the field's form tag. It returns nil
+// for any other parameter.
+func (b *Builder) structParameters(param parser.Parameter) []Parameter {
+	if param.Schema == nil || param.Schema.Ref == "" {
+		return nil
+	}
+	if param.In != "query" && param.In != "formData" {
+		return nil
+	}
+
+	fields := b.schemas.BuildStructFields(param.Schema.Ref, formTagKeys...)
+	if fields == nil {
+		return nil
+	}
+
+	params := make([]Parameter, 0, len(fields))
+	for _, field := range fields {
+		description := field.Schema.Description
+		field.Schema.Description = ""
+		params = append(params, Parameter{
+			Name:        field.Name,
+			In:          param.In,
+			Description: description,
+			Required:    field.Required,
+			Schema:      field.Schema,
+		})
+	}
+
+	return params
+}
+
+// convertSchema converts a parser schema into an OpenAPI schema, building
+// component schemas for the Go types it references. A reference with field
+// overrides, such as Resp{data=[]User}, becomes an allOf of the referenced
+// component and an object holding the overridden properties.
+func (b *Builder) convertSchema(s *parser.Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	if s.Ref != "" {
+		ref := b.schemas.BuildSchema(s.Ref)
+		if len(s.Properties) == 0 {
+			return ref
+		}
+		return &Schema{AllOf: []*Schema{ref, {
+			Type:       "object",
+			Properties: b.convertProperties(s.Properties),
+		}}}
+	}
+
+	if s.Type == "file" {
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	schema := &Schema{
+		Type:        s.Type,
+		Format:      s.Format,
+		Description: s.Description,
+		Required:    s.Required,
+		Items:       b.convertSchema(s.Items),
+		Minimum:     s.Minimum,
+		Maximum:     s.Maximum,
+		MinLength:   s.MinLength,
+		MaxLength:   s.MaxLength,
+		Pattern:     s.Pattern,
+	}
+
+	for _, value := range s.Enum {
+		schema.Enum = append(schema.Enum, typedValue(s.Type, value))
+	}
+	if s.Default != "" {
+		schema.Default = typedValue(s.Type, s.Default)
+	}
+	if s.Example != "" {
+		schema.Example = typedValue(s.Type, s.Example)
+	}
+
+	if len(s.Properties) > 0 {
+		schema.Properties = b.convertProperties(s.Properties)
+	}
+
+	return schema
+}
+
+// convertProperties converts the properties of a parser schema.
+func (b *Builder) convertProperties(props map[string]*parser.Schema) map[string]*Schema {
+	result := make(map[string]*Schema, len(props))
+	for name, prop := range props {
+		result[name] = b.convertSchema(prop)
+	}
+	return result
+}
+
+// syncComponents copies the schemas built so far into the document
+// components, dropping components the schema builder has since renamed.
+func (b *Builder) syncComponents() {
+	schemas := b.schemas.GetSchemas()
+	for name := range b.synced {
+		if _, ok := schemas[name]; !ok {
+			delete(b.doc.Components.Schemas, name)
+			delete(b.synced, name)
+		}
+	}
+
+	for name, schema := range schemas {
+		b.doc.Components.Schemas[name] = schema
+		b.synced[name] = true
+	}
+}
+
 // hasTag checks if a tag already exists in the document.
 func (b *Builder) hasTag(tagName string) bool {
 	for _, tag := range b.doc.Tags {
@@ -149,6 +519,179 @@ func (b *Builder) SetInfo(info Info) {
 	b.doc.Info = info
 }
 
+// SetGeneralInfo fills the document info, servers and external docs from the
+// general API info annotations. Empty fields keep their current values.
+func (b *Builder) SetGeneralInfo(info *parser.GeneralInfo) {
+	if info == nil {
+		return
+	}
+
+	if info.Title != "" {
+		b.doc.Info.Title = info.Title
+	}
+	if info.Version != "" {
+		b.doc.Info.Version = info.Version
+	}
+	if info.Description != "" {
+		b.doc.Info.Description = info.Description
+	}
+	if info.TermsOfService != "" {
+		b.doc.Info.TermsOfService = info.TermsOfService
+	}
+	if info.Contact != (parser.Contact{}) {
+		b.doc.Info.Contact = &Contact{
+			Name:  info.Contact.Name,
+			URL:   info.Contact.URL,
+			Email: info.Contact.Email,
+		}
+	}
+	if info.License.Name != "" {
+		b.doc.Info.License = &License{
+			Name: info.License.Name,
+			URL:  info.License.URL,
+		}
+	}
+	if info.ExternalDocs.URL != "" {
+		b.doc.ExternalDocs = &ExternalDocs{
+			Description: info.ExternalDocs.Description,
+			URL:         info.ExternalDocs.URL,
+		}
+	}
+
+	for _, server := range generalInfoServers(info) {
+		b.AddServer(server)
+	}
+
+	for _, scheme := range info.SecuritySchemes {
+		b.AddSecurityScheme(scheme.Name, convertSecurityScheme(scheme))
+	}
+
+	for _, tag := range info.Tags {
+		declared := Tag{Name: tag.Name, Description: tag.Description}
+		if tag.ExternalDocs.URL != "" {
+			declared.ExternalDocs = &ExternalDocs{
+				Description: tag.ExternalDocs.Description,
+				URL:         tag.ExternalDocs.URL,
+			}
+		}
+		b.addTag(declared, true)
+
+		if tag.Group != "" {
+			b.AddTagGroup(tag.Group, tag.Name)
+		}
+	}
+	if len(info.Security) > 0 {
+		b.SetDefaultSecurity(info.Security)
+	}
+}
+
+// AddSecurityScheme adds a security scheme to the components.
+func (b *Builder) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	if b.doc.Components.SecuritySchemes == nil {
+		b.doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	b.doc.Components.SecuritySchemes[name] = scheme
+}
+
+// SetDefaultSecurity sets the security requirements applied to operations
+// that do not declare their own.
+func (b *Builder) SetDefaultSecurity(requirements []parser.SecurityRequirement) {
+	b.doc.Security = convertSecurity(requirements)
+}
+
+// convertSecurityScheme converts a parsed @securityDefinitions annotation to
+// an OpenAPI security scheme.
+func convertSecurityScheme(s parser.SecurityScheme) *SecurityScheme {
+	scheme := &SecurityScheme{Description: s.Description}
+
+	switch s.Type {
+	case "basic":
+		scheme.Type, scheme.Scheme = "http", "basic"
+	case "bearer":
+		scheme.Type, scheme.Scheme, scheme.BearerFormat = "http", "bearer", s.BearerFormat
+	case "apikey":
+		scheme.Type, scheme.In, scheme.Name = "apiKey", s.In, s.ParamName
+	case "oauth2":
+		scheme.Type = "oauth2"
+
+		scopes := s.Scopes
+		if scopes == nil {
+			scopes = make(map[string]string)
+		}
+		flow := &OAuthFlow{
+			AuthorizationURL: s.AuthorizationURL,
+			TokenURL:         s.TokenURL,
+			RefreshURL:       s.RefreshURL,
+			Scopes:           scopes,
+		}
+
+		switch s.Flow {
+		case "application":
+			scheme.Flows = &OAuthFlows{ClientCredentials: flow}
+		case "implicit":
+			scheme.Flows = &OAuthFlows{Implicit: flow}
+		case "password":
+			scheme.Flows = &OAuthFlows{Password: flow}
+		case "accessCode":
+			scheme.Flows = &OAuthFlows{AuthorizationCode: flow}
+		}
+	}
+
+	return scheme
+}
+
+// convertSecurity converts parsed security requirements. Scheme entries
+// without scopes are kept as empty arrays, as OpenAPI requires.
+func convertSecurity(requirements []parser.SecurityRequirement) []SecurityRequirement {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	converted := make([]SecurityRequirement, 0, len(requirements))
+	for _, requirement := range requirements {
+		req := make(SecurityRequirement, len(requirement))
+		for name, scopes := range requirement {
+			if scopes == nil {
+				scopes = []string{}
+			}
+			req[name] = scopes
+		}
+		converted = append(converted, req)
+	}
+	return converted
+}
+
+// generalInfoServers returns the servers declared with @server, or derives
+// them from @host, @BasePath and @schemes. Without schemes the URL is
+// protocol-relative, so it follows the scheme the document is served with.
+func generalInfoServers(info *parser.GeneralInfo) []Server {
+	if len(info.Servers) > 0 {
+		servers := make([]Server, 0, len(info.Servers))
+		for _, server := range info.Servers {
+			servers = append(servers, Server{URL: server.URL, Description: server.Description})
+		}
+		return servers
+	}
+
+	if info.Host == "" {
+		if info.BasePath == "" {
+			return nil
+		}
+		return []Server{{URL: info.BasePath}}
+	}
+
+	basePath := strings.TrimSuffix(info.BasePath, "/")
+	if len(info.Schemes) == 0 {
+		return []Server{{URL: "//" + info.Host + basePath}}
+	}
+
+	servers := make([]Server, 0, len(info.Schemes))
+	for _, scheme := range info.Schemes {
+		servers = append(servers, Server{URL: scheme + "://" + info.Host + basePath})
+	}
+	return servers
+}
+
 // AddServer adds a server to the documentation.
 func (b *Builder) AddServer(server Server) {
 	b.doc.Servers = append(b.doc.Servers, server)
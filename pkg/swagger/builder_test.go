@@ -2,11 +2,16 @@ package swagger
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/neglet30/swag-gen/pkg/config"
 	"github.com/neglet30/swag-gen/pkg/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestNewBuilder(t *testing.T) {
@@ -119,6 +124,75 @@ func TestBuilderAddEndpointWithParameters(t *testing.T) {
 	assert.True(t, pathItem.Get.Parameters[0].Required)
 }
 
+func TestBuilderAddEndpoint_ParameterAttributes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	cp := parser.NewCommentParser(logger)
+
+	endpoint := cp.ParseEndpoint([]string{
+		`// @Router /users [GET]`,
+		`// @Param sort query string false "sort" Enums(asc, desc) default(asc)`,
+		`// @Param page query int false "page" minimum(1) maximum(100) default(1) example(2)`,
+		`// @Param ids query []int false "ids" collectionFormat(csv) Enums(1, 2, 3)`,
+		`// @Param tags query []string false "tags" collectionFormat(multi)`,
+		`// @Param X-Request-ID header string false "id" format(uuid)`,
+		`// @Param states query []string false "states"`,
+	}, "api.go", 1)
+	require.NotNil(t, endpoint)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	require.NoError(t, builder.AddEndpoint(endpoint))
+
+	params := builder.doc.Paths["/users"].Get.Parameters
+	require.Len(t, params, 6)
+
+	assert.Equal(t, []interface{}{"asc", "desc"}, params[0].Schema.Enum)
+	assert.Equal(t, "asc", params[0].Schema.Default)
+
+	assert.Equal(t, 1.0, *params[1].Schema.Minimum)
+	assert.Equal(t, 100.0, *params[1].Schema.Maximum)
+	assert.Equal(t, int64(1), params[1].Schema.Default)
+	assert.Equal(t, int64(2), params[1].Schema.Example)
+
+	assert.Equal(t, "form", params[2].Style)
+	require.NotNil(t, params[2].Explode)
+	assert.False(t, *params[2].Explode)
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, params[2].Schema.Items.Enum)
+
+	assert.Equal(t, "form", params[3].Style)
+	require.NotNil(t, params[3].Explode)
+	assert.True(t, *params[3].Explode)
+
+	assert.Equal(t, "uuid", params[4].Schema.Format)
+	assert.Empty(t, params[4].Style)
+
+	// Arrays without a collectionFormat default to csv
+	assert.Equal(t, "form", params[5].Style)
+	require.NotNil(t, params[5].Explode)
+	assert.False(t, *params[5].Explode)
+}
+
+func TestBuilderAddEndpoint_Duplicate(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	require.NoError(t, builder.AddEndpoint(&parser.Endpoint{Method: "GET", Path: "/users", Summary: "first"}))
+	require.NoError(t, builder.AddEndpoint(&parser.Endpoint{Method: "POST", Path: "/users"}))
+
+	err := builder.AddEndpoint(&parser.Endpoint{Method: "GET", Path: "/users", Summary: "second"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	assert.Equal(t, "first", builder.doc.Paths["/users"].Get.Summary)
+}
+
+func TestBuilderAddEndpoint_OperationID(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	require.NoError(t, builder.AddEndpoint(&parser.Endpoint{Method: "GET", Path: "/users", OperationID: "listUsers"}))
+
+	data, err := builder.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"operationId": "listUsers"`)
+}
+
 func TestBuilderAddEndpointWithResponses(t *testing.T) {
 	builder := NewBuilder("Test API", "1.0.0", "")
 
@@ -755,3 +829,842 @@ func TestBuilderComplexScenario(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, yamlData)
 }
+
+func TestBuilderAddEndpoint_ResolvesTypeReferences(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/user.go": `package model
+
+type User struct {
+	ID      int
+	Friends []*User
+	Address Address
+}
+
+type Address struct {
+	City string
+}
+`,
+		"api/user.go": `package api
+
+import "example.com/shop/model"
+
+// @Router /users/{id} [GET]
+// @Success 200 {object} model.User "成功"
+// @Success 206 {array} model.User "部分内容"
+// @Failure 404 "不存在"
+func GetUser() {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(p.Types())
+	require.NoError(t, builder.AddEndpoint(endpoints[0]))
+
+	op := builder.doc.Paths["/users/{id}"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, "#/components/schemas/User", op.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/User", op.Responses["206"].Content["application/json"].Schema.Items.Ref)
+	assert.Empty(t, op.Responses["404"].Content)
+
+	user := builder.doc.Components.Schemas["User"]
+	require.NotNil(t, user)
+	assert.Equal(t, "object", user.Type)
+	assert.Equal(t, "#/components/schemas/User", user.Properties["Friends"].Items.Ref)
+	assert.Equal(t, "#/components/schemas/Address", user.Properties["Address"].Ref)
+	assert.NotNil(t, builder.doc.Components.Schemas["Address"])
+}
+
+func TestBuilderAddEndpoint_UnresolvedTypeReference(t *testing.T) {
+	types := parser.NewTypeRegistry(zap.NewNop())
+	require.NoError(t, types.LoadModule(t.TempDir()))
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(types)
+
+	endpoint := &parser.Endpoint{
+		Path:   "/users",
+		Method: "GET",
+		Responses: map[string]parser.Response{
+			"200": {StatusCode: "200", Description: "Success", Schema: &parser.Schema{Type: "object", Ref: "User"}},
+		},
+	}
+
+	require.NoError(t, builder.AddEndpoint(endpoint))
+
+	// Unresolved types have no component, so no dangling reference is emitted
+	schema := builder.doc.Paths["/users"].Get.Responses["200"].Content["application/json"].Schema
+	assert.Empty(t, schema.Ref)
+	assert.Equal(t, "object", schema.Type)
+	assert.Empty(t, builder.doc.Components.Schemas)
+}
+
+func TestBuilderAddEndpoint_StructTags(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"api/user.go": `package api
+
+// @Router /users [GET]
+// @Param filter query ListFilter false "筛选条件"
+// @Success 200 {object} User "成功"
+func ListUsers() {}
+
+type User struct {
+	ID       int64   ` + "`json:\"id,string\"`" + `
+	UserName string  ` + "`json:\"user_name\"`" + `
+	Nickname string  ` + "`json:\"nickname,omitempty\"`" + `
+	Email    *string ` + "`json:\"email\"`" + `
+	Password string  ` + "`json:\"-\"`" + `
+	secret   string
+}
+
+type ListFilter struct {
+	// 页码
+	Page    int    ` + "`form:\"page\" json:\"p\" binding:\"min=1\"`" + `
+	Size    int    ` + "`form:\"size\" binding:\"required\"`" + `
+	Keyword string ` + "`query:\"q,omitempty\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(p.Types())
+	require.NoError(t, builder.AddEndpoint(endpoints[0]))
+
+	user := builder.doc.Components.Schemas["User"]
+	require.NotNil(t, user)
+	assert.Len(t, user.Properties, 4)
+	assert.Equal(t, "string", user.Properties["id"].Type)
+	assert.Contains(t, user.Properties, "user_name")
+	assert.Contains(t, user.Properties, "nickname")
+	assert.Contains(t, user.Properties, "email")
+	assert.ElementsMatch(t, []string{"id", "user_name"}, user.Required)
+
+	params := builder.doc.Paths["/users"].Get.Parameters
+	require.Len(t, params, 3)
+	assert.Equal(t, "page", params[0].Name)
+	assert.Equal(t, "query", params[0].In)
+	assert.Equal(t, "页码", params[0].Description)
+	assert.Equal(t, "integer", params[0].Schema.Type)
+	assert.False(t, params[0].Required, "form fields are optional unless validated as required")
+	assert.Equal(t, "size", params[1].Name)
+	assert.True(t, params[1].Required)
+	assert.Equal(t, "q", params[2].Name)
+	assert.False(t, params[2].Required)
+}
+
+func TestBuilderSetGeneralInfo(t *testing.T) {
+	builder := NewBuilder("Default", "1.0.0", "Default description")
+
+	builder.SetGeneralInfo(&parser.GeneralInfo{
+		Title:          "Shop API",
+		Version:        "2.0",
+		TermsOfService: "https://example.com/terms",
+		Contact:        parser.Contact{Name: "API Support", Email: "support@example.com"},
+		License:        parser.License{Name: "MIT"},
+		Host:           "api.example.com",
+		BasePath:       "/api/v1/",
+		Schemes:        []string{"https", "http"},
+		ExternalDocs:   parser.ExternalDocs{URL: "https://example.com/docs"},
+	})
+
+	doc := builder.Build()
+	assert.Equal(t, "Shop API", doc.Info.Title)
+	assert.Equal(t, "2.0", doc.Info.Version)
+	assert.Equal(t, "Default description", doc.Info.Description)
+	assert.Equal(t, "https://example.com/terms", doc.Info.TermsOfService)
+	require.NotNil(t, doc.Info.Contact)
+	assert.Equal(t, "support@example.com", doc.Info.Contact.Email)
+	require.NotNil(t, doc.Info.License)
+	assert.Equal(t, "MIT", doc.Info.License.Name)
+	require.NotNil(t, doc.ExternalDocs)
+	assert.Equal(t, "https://example.com/docs", doc.ExternalDocs.URL)
+	assert.Equal(t, []Server{
+		{URL: "https://api.example.com/api/v1"},
+		{URL: "http://api.example.com/api/v1"},
+	}, doc.Servers)
+}
+
+func TestGeneralInfoServers(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     parser.GeneralInfo
+		expected []Server
+	}{
+		{"none", parser.GeneralInfo{}, nil},
+		{"base path only", parser.GeneralInfo{BasePath: "/api"}, []Server{{URL: "/api"}}},
+		{"host without schemes", parser.GeneralInfo{Host: "localhost:8080", BasePath: "/api"}, []Server{{URL: "//localhost:8080/api"}}},
+		{
+			"explicit servers",
+			parser.GeneralInfo{Host: "ignored", Servers: []parser.Server{{URL: "https://a.example.com", Description: "A"}}},
+			[]Server{{URL: "https://a.example.com", Description: "A"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, generalInfoServers(&tt.info))
+		})
+	}
+}
+
+func TestBuilderSecurity(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	builder.SetGeneralInfo(&parser.GeneralInfo{
+		SecuritySchemes: []parser.SecurityScheme{
+			{Name: "BasicAuth", Type: "basic"},
+			{Name: "BearerAuth", Type: "bearer", BearerFormat: "JWT"},
+			{Name: "ApiKeyAuth", Type: "apikey", In: "header", ParamName: "X-API-Key"},
+			{Name: "OAuth2", Type: "oauth2", Flow: "application", TokenURL: "https://example.com/token"},
+		},
+		Security: []parser.SecurityRequirement{{"BearerAuth": {}}},
+	})
+
+	err := builder.AddEndpoint(&parser.Endpoint{
+		Method:    "GET",
+		Path:      "/users",
+		Responses: map[string]parser.Response{},
+		Security:  []parser.SecurityRequirement{{"OAuth2": {"read"}}, {"ApiKeyAuth": nil}},
+	})
+	require.NoError(t, err)
+
+	doc := builder.Build()
+	schemes := doc.Components.SecuritySchemes
+	require.Len(t, schemes, 4)
+	assert.Equal(t, &SecurityScheme{Type: "http", Scheme: "basic"}, schemes["BasicAuth"])
+	assert.Equal(t, &SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}, schemes["BearerAuth"])
+	assert.Equal(t, &SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}, schemes["ApiKeyAuth"])
+	require.NotNil(t, schemes["OAuth2"].Flows)
+	require.NotNil(t, schemes["OAuth2"].Flows.ClientCredentials)
+	assert.Equal(t, "https://example.com/token", schemes["OAuth2"].Flows.ClientCredentials.TokenURL)
+	assert.NotNil(t, schemes["OAuth2"].Flows.ClientCredentials.Scopes)
+
+	assert.Equal(t, []SecurityRequirement{{"BearerAuth": {}}}, doc.Security)
+	assert.Equal(t, []SecurityRequirement{
+		{"OAuth2": {"read"}},
+		{"ApiKeyAuth": {}},
+	}, doc.Paths["/users"].Get.Security)
+
+	// Schemes without scopes are serialized as empty arrays
+	data, err := builder.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"ApiKeyAuth": []`)
+}
+
+func TestBuilderAddEndpoint_MediaTypes(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	err := builder.AddEndpoint(&parser.Endpoint{
+		Method:  "POST",
+		Path:    "/users",
+		Accept:  []string{"application/json", "text/xml"},
+		Produce: []string{"application/xml"},
+		Parameters: []parser.Parameter{
+			{Name: "user", In: "body", Type: "object", Required: true, Description: "User to create", Schema: &parser.Schema{Type: "object"}},
+			{Name: "dryRun", In: "query", Type: "boolean"},
+		},
+		Responses: map[string]parser.Response{
+			"201": {StatusCode: "201", Description: "Created", Schema: &parser.Schema{Type: "string"}},
+			"204": {StatusCode: "204", Description: "No Content"},
+		},
+	})
+	require.NoError(t, err)
+
+	op := builder.Build().Paths["/users"].Post
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, "dryRun", op.Parameters[0].Name)
+
+	require.NotNil(t, op.RequestBody)
+	assert.True(t, op.RequestBody.Required)
+	assert.Equal(t, "User to create", op.RequestBody.Description)
+	assert.Len(t, op.RequestBody.Content, 2)
+	assert.Equal(t, "object", op.RequestBody.Content["text/xml"].Schema.Type)
+
+	require.Len(t, op.Responses["201"].Content, 1)
+	assert.Equal(t, "string", op.Responses["201"].Content["application/xml"].Schema.Type)
+	assert.Empty(t, op.Responses["204"].Content)
+}
+
+func TestBuilderSetDefaultMediaTypes(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetDefaultMediaTypes(nil, []string{"application/json", "text/plain"})
+
+	err := builder.AddEndpoint(&parser.Endpoint{
+		Method:     "POST",
+		Path:       "/echo",
+		Parameters: []parser.Parameter{{Name: "body", In: "body", Type: "string"}},
+		Responses: map[string]parser.Response{
+			"200": {StatusCode: "200", Description: "OK", Schema: &parser.Schema{Type: "string"}},
+		},
+	})
+	require.NoError(t, err)
+
+	op := builder.Build().Paths["/echo"].Post
+	assert.Contains(t, op.RequestBody.Content, "application/json")
+	assert.Len(t, op.RequestBody.Content, 1)
+	assert.Len(t, op.Responses["200"].Content, 2)
+	assert.Contains(t, op.Responses["200"].Content, "text/plain")
+}
+
+func TestBuilderAddEndpoint_FormDataRequestBody(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	err := builder.AddEndpoint(&parser.Endpoint{
+		Method: "POST",
+		Path:   "/upload",
+		Parameters: []parser.Parameter{
+			{Name: "file", In: "formData", Type: "file", Required: true, Description: "File to upload", Schema: &parser.Schema{Type: "file"}},
+			{Name: "tags", In: "formData", Type: "array", Schema: &parser.Schema{Type: "array", Items: &parser.Schema{Type: "string"}}},
+			{Name: "id", In: "query", Type: "int", Schema: &parser.Schema{Type: "integer"}},
+		},
+		Responses: map[string]parser.Response{},
+	})
+	require.NoError(t, err)
+
+	op := builder.Build().Paths["/upload"].Post
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, "query", op.Parameters[0].In)
+
+	require.NotNil(t, op.RequestBody)
+	assert.True(t, op.RequestBody.Required)
+	require.Len(t, op.RequestBody.Content, 1)
+
+	media, ok := op.RequestBody.Content["multipart/form-data"]
+	require.True(t, ok)
+	assert.Equal(t, "object", media.Schema.Type)
+	assert.Equal(t, []string{"file"}, media.Schema.Required)
+	assert.Equal(t, &Schema{Type: "string", Format: "binary", Description: "File to upload"}, media.Schema.Properties["file"])
+	assert.Equal(t, "array", media.Schema.Properties["tags"].Type)
+	assert.Equal(t, map[string]Encoding{"file": {ContentType: "application/octet-stream"}}, media.Encoding)
+}
+
+func TestBuilderAddEndpoint_URLEncodedFormBody(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	err := builder.AddEndpoint(&parser.Endpoint{
+		Method: "POST",
+		Path:   "/login",
+		Parameters: []parser.Parameter{
+			{Name: "username", In: "formData", Type: "string", Required: true, Schema: &parser.Schema{Type: "string"}},
+			{Name: "scopes", In: "formData", Type: "array", Schema: &parser.Schema{Type: "array", Items: &parser.Schema{Type: "string"}}},
+		},
+		Responses: map[string]parser.Response{},
+	})
+	require.NoError(t, err)
+
+	op := builder.Build().Paths["/login"].Post
+	assert.Empty(t, op.Parameters)
+	require.NotNil(t, op.RequestBody)
+
+	media, ok := op.RequestBody.Content["application/x-www-form-urlencoded"]
+	require.True(t, ok)
+	assert.Equal(t, []string{"username"}, media.Schema.Required)
+	require.Contains(t, media.Encoding, "scopes")
+	assert.Equal(t, "form", media.Encoding["scopes"].Style)
+	assert.False(t, *media.Encoding["scopes"].Explode)
+}
+
+func TestFormMediaTypes(t *testing.T) {
+	assert.Equal(t, []string{"multipart/form-data"}, formMediaTypes(nil, true))
+	assert.Equal(t, []string{"application/x-www-form-urlencoded"}, formMediaTypes([]string{"application/json"}, false))
+	assert.Equal(t, []string{"multipart/form-data", "application/x-www-form-urlencoded"},
+		formMediaTypes([]string{"multipart/form-data", "application/x-www-form-urlencoded"}, false))
+	assert.Equal(t, []string{"multipart/form-data"}, formMediaTypes([]string{"application/x-www-form-urlencoded"}, true))
+}
+
+func TestBuilderAddEndpoint_AnnotatedRequestBodies(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"api/user.go": `package api
+
+// @Router /users [POST]
+// @Param body body CreateUserRequest true "User to create"
+// @Success 201 {object} CreateUserRequest
+func CreateUser() {}
+
+// @Router /avatars [POST]
+// @Accept mpfd
+// @Param form formData AvatarForm true "Avatar"
+func UploadAvatar() {}
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\" binding:\"required\"`" + `
+}
+
+type AvatarForm struct {
+	UserID int    ` + "`form:\"user_id\" binding:\"required\"`" + `
+	Note   string ` + "`form:\"note,omitempty\"`" + `
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(p.Types())
+	for _, endpoint := range endpoints {
+		require.NoError(t, builder.AddEndpoint(endpoint))
+	}
+
+	create := builder.doc.Paths["/users"].Post
+	assert.Empty(t, create.Parameters)
+	require.NotNil(t, create.RequestBody)
+	assert.True(t, create.RequestBody.Required)
+	assert.Equal(t, "#/components/schemas/CreateUserRequest", create.RequestBody.Content["application/json"].Schema.Ref)
+
+	upload := builder.doc.Paths["/avatars"].Post
+	assert.Empty(t, upload.Parameters)
+	require.NotNil(t, upload.RequestBody)
+	media, ok := upload.RequestBody.Content["multipart/form-data"]
+	require.True(t, ok)
+	assert.Len(t, media.Schema.Properties, 2)
+	assert.Equal(t, "integer", media.Schema.Properties["user_id"].Type)
+	assert.Equal(t, []string{"user_id"}, media.Schema.Required)
+}
+
+func TestBuilderAddEndpoint_ResponseHeaders(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	err := builder.AddEndpoint(&parser.Endpoint{
+		Method: "POST",
+		Path:   "/login",
+		Responses: map[string]parser.Response{
+			"204": {
+				StatusCode:  "204",
+				Description: "No Content",
+				Headers: map[string]parser.Header{
+					"Set-Cookie":    {Type: "string", Description: "Session cookie", Schema: &parser.Schema{Type: "string"}},
+					"X-Total-Count": {Type: "integer", Schema: &parser.Schema{Type: "integer"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	headers := builder.Build().Paths["/login"].Post.Responses["204"].Headers
+	require.Len(t, headers, 2)
+	assert.Equal(t, Header{Description: "Session cookie", Schema: &Schema{Type: "string"}}, headers["Set-Cookie"])
+	assert.Equal(t, "integer", headers["X-Total-Count"].Schema.Type)
+}
+
+func TestBuilderAddEndpoint_GenericTypes(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/user.go": `package model
+
+type User struct {
+	ID int
+}
+
+type Post struct {
+	Title string
+}
+`,
+		"api/result.go": `package api
+
+import "example.com/shop/model"
+
+// Result wraps every response.
+type Result[T any] struct {
+	Code int
+	Data T
+}
+
+type Page[T any] struct {
+	Items []T
+	Total int
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value *V
+}
+
+// @Router /users/{id} [GET]
+// @Success 200 {object} Result[model.User]
+// @Success 206 {object} Result[Page[model.Post]]
+// @Failure 400 {object} Result[string]
+func GetUser() {}
+
+// @Router /pairs [GET]
+// @Success 200 {array} Pair[string,model.User]
+func ListPairs() {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(p.Types())
+	for _, endpoint := range endpoints {
+		require.NoError(t, builder.AddEndpoint(endpoint))
+	}
+
+	op := builder.doc.Paths["/users/{id}"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, "#/components/schemas/Result_User", op.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Result_Page_Post", op.Responses["206"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Result_string", op.Responses["400"].Content["application/json"].Schema.Ref)
+
+	schemas := builder.doc.Components.Schemas
+	require.Contains(t, schemas, "Result_User")
+	assert.Equal(t, "Result wraps every response.", schemas["Result_User"].Description)
+	assert.Equal(t, "#/components/schemas/User", schemas["Result_User"].Properties["Data"].Ref)
+	assert.Equal(t, "string", schemas["Result_string"].Properties["Data"].Type)
+	assert.Equal(t, "#/components/schemas/Page_Post", schemas["Result_Page_Post"].Properties["Data"].Ref)
+	assert.Equal(t, "#/components/schemas/Post", schemas["Page_Post"].Properties["Items"].Items.Ref)
+	assert.NotContains(t, schemas, "Result")
+
+	pairs := builder.doc.Paths["/pairs"].Get.Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Pair_string_User", pairs.Items.Ref)
+	pair := schemas["Pair_string_User"]
+	require.NotNil(t, pair)
+	assert.Equal(t, "string", pair.Properties["Key"].Type)
+	assert.Equal(t, "#/components/schemas/User", pair.Properties["Value"].Ref)
+}
+
+func TestBuilderAddEndpoint_Enums(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/order.go": `package model
+
+// OrderStatus is the lifecycle state of an order.
+type OrderStatus string
+
+const (
+	StatusPending OrderStatus = "pending" // Waiting for payment
+	StatusPaid    OrderStatus = "paid"    // Paid in full
+)
+
+type Level int
+
+const (
+	LevelLow Level = iota
+	LevelHigh
+)
+
+type Order struct {
+	Status OrderStatus
+	Level  Level
+}
+`,
+		"api/order.go": `package api
+
+import "example.com/shop/model"
+
+// @Router /orders/{id} [GET]
+// @Success 200 {object} model.Order
+func GetOrder() {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(p.Types())
+	require.NoError(t, builder.AddEndpoint(endpoints[0]))
+
+	schemas := builder.doc.Components.Schemas
+	assert.Equal(t, "#/components/schemas/OrderStatus", schemas["Order"].Properties["Status"].Ref)
+
+	status := schemas["OrderStatus"]
+	require.NotNil(t, status)
+	assert.Equal(t, "string", status.Type)
+	assert.Equal(t, "OrderStatus is the lifecycle state of an order.", status.Description)
+	assert.Equal(t, []interface{}{"pending", "paid"}, status.Enum)
+	assert.Equal(t, []string{"StatusPending", "StatusPaid"}, status.EnumVarNames)
+	assert.Equal(t, []string{"Waiting for payment", "Paid in full"}, status.EnumDescriptions)
+
+	level := schemas["Level"]
+	require.NotNil(t, level)
+	assert.Equal(t, []interface{}{int64(0), int64(1)}, level.Enum)
+	assert.Equal(t, []string{"LevelLow", "LevelHigh"}, level.EnumVarNames)
+	assert.Nil(t, level.EnumDescriptions)
+
+	data, err := builder.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"x-enum-varnames"`)
+}
+
+func TestBuilderAddEndpoint_EmbeddedStructs(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/base.go": `package model
+
+import "time"
+
+type BaseModel struct {
+	ID        int64     ` + "`json:\"id\"`" + `
+	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
+	UpdatedAt time.Time ` + "`json:\"updated_at\"`" + `
+}
+
+type Audit struct {
+	CreatedBy string ` + "`json:\"created_by\"`" + `
+	Name      string
+}
+
+type Meta struct {
+	Version int ` + "`json:\"version\"`" + `
+}
+
+type User struct {
+	BaseModel
+	*Audit
+	Meta  ` + "`json:\"meta\"`" + `
+	Name  string ` + "`json:\"name\"`" + `
+}
+`,
+		"api/user.go": `package api
+
+import "example.com/shop/model"
+
+// @Router /users/{id} [GET]
+// @Success 200 {object} model.User
+func GetUser() {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	t.Run("flatten", func(t *testing.T) {
+		builder := NewBuilder("Test API", "1.0.0", "")
+		builder.SetTypeRegistry(p.Types())
+		require.NoError(t, builder.AddEndpoint(endpoints[0]))
+
+		user := builder.doc.Components.Schemas["User"]
+		require.NotNil(t, user)
+		assert.Empty(t, user.AllOf)
+		assert.ElementsMatch(t, []string{"id", "created_at", "updated_at", "created_by", "Name", "meta", "name"}, keys(user.Properties))
+		assert.Equal(t, "#/components/schemas/Meta", user.Properties["meta"].Ref)
+		// Fields promoted through *Audit are optional
+		assert.ElementsMatch(t, []string{"id", "created_at", "updated_at", "meta", "name"}, user.Required)
+	})
+
+	t.Run("allOf", func(t *testing.T) {
+		builder := NewBuilder("Test API", "1.0.0", "")
+		builder.SetTypeRegistry(p.Types())
+		builder.SetEmbeddedAllOf(true)
+		require.NoError(t, builder.AddEndpoint(endpoints[0]))
+
+		user := builder.doc.Components.Schemas["User"]
+		require.NotNil(t, user)
+		require.Len(t, user.AllOf, 3)
+		assert.Equal(t, "#/components/schemas/BaseModel", user.AllOf[0].Ref)
+		assert.Equal(t, "#/components/schemas/Audit", user.AllOf[1].Ref)
+		assert.ElementsMatch(t, []string{"meta", "name"}, keys(user.AllOf[2].Properties))
+		assert.NotNil(t, builder.doc.Components.Schemas["BaseModel"])
+		assert.NotNil(t, builder.doc.Components.Schemas["Audit"])
+	})
+}
+
+func TestBuilderAddEndpoint_ResponseOverrides(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.22\n",
+		"model/model.go": `package model
+
+type Resp struct {
+	Code    int         ` + "`json:\"code\"`" + `
+	Message string      ` + "`json:\"message\"`" + `
+	Data    interface{} ` + "`json:\"data\"`" + `
+}
+
+type Page struct {
+	Total int64       ` + "`json:\"total\"`" + `
+	Items interface{} ` + "`json:\"items\"`" + `
+}
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+		"api/user.go": `package api
+
+import "example.com/shop/model"
+
+// @Router /users [GET]
+// @Success 200 {object} model.Resp{data=model.Page{items=[]model.User}}
+func ListUsers() {}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	p := parser.NewParser(&config.Config{}, zap.NewNop())
+	endpoints, err := p.ParseProject(root)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(p.Types())
+	require.NoError(t, builder.AddEndpoint(endpoints[0]))
+
+	schema := builder.doc.Paths["/users"].Get.Responses["200"].Content["application/json"].Schema
+	require.Len(t, schema.AllOf, 2)
+	assert.Equal(t, "#/components/schemas/Resp", schema.AllOf[0].Ref)
+	assert.Equal(t, "object", schema.AllOf[1].Type)
+
+	data := schema.AllOf[1].Properties["data"]
+	require.NotNil(t, data)
+	require.Len(t, data.AllOf, 2)
+	assert.Equal(t, "#/components/schemas/Page", data.AllOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/User", data.AllOf[1].Properties["items"].Items.Ref)
+
+	for _, name := range []string{"Resp", "Page", "User"} {
+		assert.NotNil(t, builder.doc.Components.Schemas[name], name)
+	}
+}
+
+func keys(m map[string]*Schema) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	return result
+}
+
+// writeCollisionModule writes a module declaring User in three packages, two
+// of which share the package name model, and returns a registry for it.
+func writeCollisionModule(t *testing.T) *parser.TypeRegistry {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":               "module example.com/shop\n\ngo 1.22\n",
+		"api/user.go":          "package api\n\nimport \"example.com/shop/model\"\n\ntype User struct {\n\tOwner model.User\n}\n",
+		"model/user.go":        "package model\n\ntype User struct {\n\tAddress Address\n}\n\ntype Address struct {\n\tCity string\n}\n",
+		"legacy/model/user.go": "package model\n\ntype User struct {\n\tName string\n}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	types := parser.NewTypeRegistry(zap.NewNop())
+	require.NoError(t, types.LoadModule(root))
+	return types
+}
+
+func TestSchemaBuilderComponentNameCollisions(t *testing.T) {
+	types := writeCollisionModule(t)
+	ids := []string{
+		"example.com/shop/api.User",
+		"example.com/shop/model.User",
+		"example.com/shop/legacy/model.User",
+		"example.com/shop/model.Address",
+	}
+	want := map[string]string{
+		"example.com/shop/api.User":          "#/components/schemas/example.com.shop.api.User",
+		"example.com/shop/model.User":        "#/components/schemas/example.com.shop.model.User",
+		"example.com/shop/legacy/model.User": "#/components/schemas/example.com.shop.legacy.model.User",
+		"example.com/shop/model.Address":     "#/components/schemas/Address",
+	}
+
+	// The names must not depend on the order the types are built in.
+	for _, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}, {1, 0, 3, 2}} {
+		sb := NewSchemaBuilder()
+		sb.SetTypeRegistry(types)
+
+		// References handed out earlier follow later renames.
+		built := make(map[string]*Schema)
+		for _, i := range order {
+			built[ids[i]] = sb.BuildSchema(ids[i])
+		}
+		refs := make(map[string]string)
+		for id, schema := range built {
+			refs[id] = schema.Ref
+		}
+		assert.Equal(t, want, refs, order)
+
+		schemas := sb.GetSchemas()
+		assert.ElementsMatch(t, []string{
+			"example.com.shop.api.User",
+			"example.com.shop.model.User",
+			"example.com.shop.legacy.model.User",
+			"Address",
+		}, keys(schemas), order)
+		assert.Equal(t, want["example.com/shop/model.User"], schemas["example.com.shop.api.User"].Properties["Owner"].Ref, order)
+		assert.Contains(t, schemas["example.com.shop.legacy.model.User"].Properties, "Name", order)
+	}
+}
+
+func TestBuilderAddEndpoint_RenamedComponents(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+	builder.SetTypeRegistry(writeCollisionModule(t))
+
+	for i, id := range []string{"example.com/shop/model.User", "example.com/shop/api.User"} {
+		require.NoError(t, builder.AddEndpoint(&parser.Endpoint{
+			Method:    "GET",
+			Path:      fmt.Sprintf("/users%d", i),
+			Responses: map[string]parser.Response{"200": {StatusCode: "200", Schema: &parser.Schema{Ref: id}}},
+		}))
+	}
+
+	doc := builder.Build()
+	assert.NotContains(t, doc.Components.Schemas, "User")
+	assert.Contains(t, doc.Components.Schemas, "model.User")
+	assert.Contains(t, doc.Components.Schemas, "api.User")
+	assert.Equal(t, "#/components/schemas/model.User", doc.Paths["/users0"].Get.Responses["200"].Content["application/json"].Schema.Ref)
+}
@@ -0,0 +1,84 @@
+package swagger
+
+import (
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Tag keys consulted for property names, in order of precedence.
+var (
+	bodyTagKeys = []string{"json", "yaml"}
+	formTagKeys = []string{"form", "query"}
+)
+
+// fieldTag describes how a struct field is named and encoded on the wire.
+type fieldTag struct {
+	Name      string
+	Named     bool // the tag gives the name explicitly
+	Skip      bool
+	OmitEmpty bool
+	AsString  bool
+	Tag       reflect.StructTag
+}
+
+// parseFieldTag reads the first tag among keys that is present on the field.
+// Fields without any of the tags keep their Go name, as encoding/json does.
+func parseFieldTag(goName string, tag reflect.StructTag, keys ...string) fieldTag {
+	info := fieldTag{Name: goName, Tag: tag}
+
+	for _, key := range keys {
+		value, ok := tag.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		if value == "-" {
+			info.Skip = true
+			return info
+		}
+
+		parts := strings.Split(value, ",")
+		if parts[0] != "" {
+			info.Name = parts[0]
+			info.Named = true
+		}
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "omitempty", "omitzero":
+				info.OmitEmpty = true
+			case "string":
+				info.AsString = true
+			}
+		}
+		return info
+	}
+
+	return info
+}
+
+// astFieldTag returns the struct tag of an AST field.
+func astFieldTag(field *ast.Field) reflect.StructTag {
+	if field.Tag == nil {
+		return ""
+	}
+
+	value, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+
+	return reflect.StructTag(value)
+}
+
+// stringEncoded converts a schema for a field tagged with the ",string"
+// option, which encoding/json only honors for scalar types.
+func stringEncoded(schema *Schema) *Schema {
+	switch schema.Type {
+	case "integer", "number", "boolean":
+		return &Schema{Type: "string", Description: schema.Description}
+	default:
+		return schema
+	}
+}
@@ -0,0 +1,86 @@
+package swagger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		keys []string
+		want fieldTag
+	}{
+		{
+			name: "no tag keeps go name",
+			tag:  ``,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "UserName"},
+		},
+		{
+			name: "json name",
+			tag:  `json:"user_name"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "user_name", Named: true},
+		},
+		{
+			name: "json omitempty",
+			tag:  `json:"user_name,omitempty"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "user_name", Named: true, OmitEmpty: true},
+		},
+		{
+			name: "json string option without name",
+			tag:  `json:",string"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "UserName", AsString: true},
+		},
+		{
+			name: "json skip",
+			tag:  `json:"-"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "UserName", Skip: true},
+		},
+		{
+			name: "json dash name",
+			tag:  `json:"-,"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "-", Named: true},
+		},
+		{
+			name: "yaml fallback",
+			tag:  `yaml:"user_name"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "user_name", Named: true},
+		},
+		{
+			name: "json takes precedence over yaml",
+			tag:  `json:"name" yaml:"user_name"`,
+			keys: bodyTagKeys,
+			want: fieldTag{Name: "name", Named: true},
+		},
+		{
+			name: "form tag",
+			tag:  `json:"userName" form:"user_name"`,
+			keys: formTagKeys,
+			want: fieldTag{Name: "user_name", Named: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseFieldTag("UserName", tt.tag, tt.keys...)
+			tt.want.Tag = tt.tag
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStringEncoded(t *testing.T) {
+	assert.Equal(t, "string", stringEncoded(&Schema{Type: "integer", Format: "int64"}).Type)
+	assert.Empty(t, stringEncoded(&Schema{Type: "integer", Format: "int64"}).Format)
+	assert.Equal(t, "array", stringEncoded(&Schema{Type: "array"}).Type)
+}
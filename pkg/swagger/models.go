@@ -3,21 +3,25 @@ package swagger
 
 // SwaggerDoc represents an OpenAPI 3.0 document.
 type SwaggerDoc struct {
-	OpenAPI    string              `json:"openapi"`
-	Info       Info                `json:"info"`
-	Paths      map[string]PathItem `json:"paths"`
-	Components Components          `json:"components,omitempty"`
-	Servers    []Server            `json:"servers,omitempty"`
-	Tags       []Tag               `json:"tags,omitempty"`
+	OpenAPI      string                `json:"openapi"`
+	Info         Info                  `json:"info"`
+	Paths        map[string]PathItem   `json:"paths"`
+	Components   Components            `json:"components,omitempty"`
+	Servers      []Server              `json:"servers,omitempty"`
+	Security     []SecurityRequirement `json:"security,omitempty"`
+	Tags         []Tag                 `json:"tags,omitempty"`
+	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
+	TagGroups    []TagGroup            `json:"x-tagGroups,omitempty"`
 }
 
 // Info contains metadata about the API.
 type Info struct {
-	Title       string   `json:"title"`
-	Version     string   `json:"version"`
-	Description string   `json:"description,omitempty"`
-	Contact     *Contact `json:"contact,omitempty"`
-	License     *License `json:"license,omitempty"`
+	Title          string   `json:"title"`
+	Version        string   `json:"version"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
 }
 
 // Contact information for the exposed API.
@@ -39,10 +43,24 @@ type Server struct {
 	Description string `json:"description,omitempty"`
 }
 
+// ExternalDocs references external documentation.
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
 // Tag represents a tag for grouping operations.
 type Tag struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+}
+
+// TagGroup groups tags for navigation in ReDoc through the x-tagGroups
+// extension.
+type TagGroup struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
 }
 
 // PathItem describes the operations available on a single path.
@@ -57,16 +75,44 @@ type PathItem struct {
 	Trace   *Operation `json:"trace,omitempty"`
 }
 
+// httpMethods lists the HTTP methods a path item can hold operations for.
+var httpMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "TRACE"}
+
+// operation returns the operation for an HTTP method, or nil if there is none.
+func (p PathItem) operation(method string) *Operation {
+	switch method {
+	case "GET":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "DELETE":
+		return p.Delete
+	case "PATCH":
+		return p.Patch
+	case "HEAD":
+		return p.Head
+	case "OPTIONS":
+		return p.Options
+	case "TRACE":
+		return p.Trace
+	default:
+		return nil
+	}
+}
+
 // Operation describes a single API operation for a path and HTTP method.
 type Operation struct {
-	Summary     string              `json:"summary,omitempty"`
-	Description string              `json:"description,omitempty"`
-	Tags        []string            `json:"tags,omitempty"`
-	Parameters  []Parameter         `json:"parameters,omitempty"`
-	RequestBody *RequestBody        `json:"requestBody,omitempty"`
-	Responses   map[string]Response `json:"responses"`
-	Deprecated  bool                `json:"deprecated,omitempty"`
-	OperationID string              `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
 }
 
 // Parameter describes a single operation parameter.
@@ -77,6 +123,8 @@ type Parameter struct {
 	Required    bool    `json:"required,omitempty"`
 	Schema      *Schema `json:"schema,omitempty"`
 	Deprecated  bool    `json:"deprecated,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
 }
 
 // RequestBody describes a request body that can be used by the operation.
@@ -88,8 +136,16 @@ type RequestBody struct {
 
 // MediaType provides schema and examples for the media type identified by its key.
 type MediaType struct {
-	Schema  *Schema     `json:"schema,omitempty"`
-	Example interface{} `json:"example,omitempty"`
+	Schema   *Schema             `json:"schema,omitempty"`
+	Example  interface{}         `json:"example,omitempty"`
+	Encoding map[string]Encoding `json:"encoding,omitempty"`
+}
+
+// Encoding describes how a single property of a form body is serialized.
+type Encoding struct {
+	ContentType string `json:"contentType,omitempty"`
+	Style       string `json:"style,omitempty"`
+	Explode     *bool  `json:"explode,omitempty"`
 }
 
 // Response describes a single response from an API Operation.
@@ -108,29 +164,69 @@ type Header struct {
 
 // Schema represents a JSON Schema.
 type Schema struct {
-	Type        string             `json:"type,omitempty"`
-	Format      string             `json:"format,omitempty"`
-	Title       string             `json:"title,omitempty"`
-	Description string             `json:"description,omitempty"`
-	Default     interface{}        `json:"default,omitempty"`
-	Example     interface{}        `json:"example,omitempty"`
-	Items       *Schema            `json:"items,omitempty"`
-	Properties  map[string]*Schema `json:"properties,omitempty"`
-	Required    []string           `json:"required,omitempty"`
-	Enum        []interface{}      `json:"enum,omitempty"`
-	Ref         string             `json:"$ref,omitempty"`
-	AllOf       []*Schema          `json:"allOf,omitempty"`
-	OneOf       []*Schema          `json:"oneOf,omitempty"`
-	AnyOf       []*Schema          `json:"anyOf,omitempty"`
-	Not         *Schema            `json:"not,omitempty"`
-	Minimum     *float64           `json:"minimum,omitempty"`
-	Maximum     *float64           `json:"maximum,omitempty"`
-	MinLength   *int               `json:"minLength,omitempty"`
-	MaxLength   *int               `json:"maxLength,omitempty"`
-	Pattern     string             `json:"pattern,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	EnumVarNames         []string           `json:"x-enum-varnames,omitempty"`
+	EnumDescriptions     []string           `json:"x-enum-descriptions,omitempty"`
 }
 
 // Components holds a set of reusable objects for different aspects of the OAS.
 type Components struct {
-	Schemas map[string]*Schema `json:"schemas,omitempty"`
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
 }
+
+// SecurityScheme defines a security scheme that can be used by the operations.
+type SecurityScheme struct {
+	Type         string      `json:"type"` // apiKey, http, oauth2
+	Description  string      `json:"description,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	In           string      `json:"in,omitempty"`
+	Scheme       string      `json:"scheme,omitempty"`
+	BearerFormat string      `json:"bearerFormat,omitempty"`
+	Flows        *OAuthFlows `json:"flows,omitempty"`
+}
+
+// OAuthFlows configures the supported OAuth flows.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow configures a single OAuth flow.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// SecurityRequirement lists the schemes, with their required scopes, that
+// must all be satisfied. Alternatives are separate requirements.
+type SecurityRequirement map[string][]string
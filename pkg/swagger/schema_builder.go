@@ -1,23 +1,49 @@
 package swagger
 
 import (
-	"fmt"
+	"go/ast"
 	"reflect"
+	"slices"
 	"strings"
+
+	"github.com/neglet30/swag-gen/pkg/parser"
 )
 
 // SchemaBuilder is responsible for building JSON schemas from Go types.
 type SchemaBuilder struct {
-	schemas map[string]*Schema
+	schemas  map[string]*Schema
+	types    *parser.TypeRegistry
+	names    map[string]string           // type ID -> component name
+	decls    map[string]*parser.TypeDecl // type ID -> declaration
+	short    map[string][]string         // unqualified component name -> type IDs
+	refs     map[string][]*Schema        // type ID -> references handed out, updated on rename
+	allOfEmb bool                        // compose embedded structs with allOf instead of flattening
 }
 
 // NewSchemaBuilder creates a new schema builder.
 func NewSchemaBuilder() *SchemaBuilder {
 	return &SchemaBuilder{
 		schemas: make(map[string]*Schema),
+		names:   make(map[string]string),
+		decls:   make(map[string]*parser.TypeDecl),
+		short:   make(map[string][]string),
+		refs:    make(map[string][]*Schema),
 	}
 }
 
+// SetTypeRegistry sets the registry used to resolve Go type declarations.
+func (sb *SchemaBuilder) SetTypeRegistry(types *parser.TypeRegistry) {
+	sb.types = types
+}
+
+// SetEmbeddedAllOf selects how embedded structs are rendered. By default
+// their fields are flattened into the parent, as encoding/json does; when
+// enabled, the parent becomes an allOf of references to the embedded
+// components followed by its own fields.
+func (sb *SchemaBuilder) SetEmbeddedAllOf(enabled bool) {
+	sb.allOfEmb = enabled
+}
+
 // BuildSchema builds a schema from a Go type string.
 func (sb *SchemaBuilder) BuildSchema(typeStr string) *Schema {
 	return sb.buildSchemaFromType(typeStr)
@@ -47,7 +73,17 @@ func (sb *SchemaBuilder) buildSchemaFromType(typeStr string) *Schema {
 		}
 	}
 
-	// Handle basic types
+	if schema := basicSchema(typeStr); schema != nil {
+		return schema
+	}
+
+	// For custom types, return a reference
+	return sb.BuildTypeRef(typeStr)
+}
+
+// basicSchema returns the schema for a built-in or well-known Go type, or nil
+// if the type is not one of them.
+func basicSchema(typeStr string) *Schema {
 	switch typeStr {
 	case "string":
 		return &Schema{Type: "string"}
@@ -69,34 +105,694 @@ func (sb *SchemaBuilder) buildSchemaFromType(typeStr string) *Schema {
 		return &Schema{Type: "string", Format: "date-time"}
 	case "time.Duration":
 		return &Schema{Type: "string"}
-	case "interface{}":
+	case "interface{}", "any":
 		return &Schema{}
+	case "error":
+		return &Schema{Type: "string"}
 	default:
-		// For custom types, return a reference
+		return nil
+	}
+}
+
+// BuildTypeRef builds the component schema for the Go type with the given
+// fully-qualified ID and returns a reference to it. Generic instantiations
+// such as "example.com/app/api.Result[example.com/app/model.User]" get one
+// component per distinct set of type arguments. Types that cannot be
+// resolved have no component to reference and become free-form objects;
+// without a type registry they are referenced by their short name.
+func (sb *SchemaBuilder) BuildTypeRef(id string) *Schema {
+	base, args := parser.SplitTypeArgs(id)
+
+	if sb.types != nil {
+		if decl := sb.types.Lookup(base); decl != nil {
+			return sb.buildDeclRef(decl, args)
+		}
+		return &Schema{Type: "object"}
+	}
+
+	if len(args) > 0 {
+		return refSchema(typeArgName(id))
+	}
+	return refSchema(id[strings.LastIndex(id, "/")+1:])
+}
+
+// buildDeclRef registers a component for the declaration instantiated with
+// args on first use and returns a reference to it. Arguments are ignored for
+// declarations that do not take the same number of type parameters.
+func (sb *SchemaBuilder) buildDeclRef(decl *parser.TypeDecl, args []string) *Schema {
+	typeArgs := bindTypeArgs(decl, args)
+	if typeArgs == nil {
+		args = nil
+	}
+
+	id := decl.ID()
+	if len(args) > 0 {
+		id += "[" + strings.Join(args, ",") + "]"
+	}
+	if _, ok := sb.names[id]; ok {
+		return sb.componentRef(id)
+	}
+
+	short := componentName(decl, args)
+	sb.decls[id] = decl
+	sb.short[short] = append(sb.short[short], id)
+	sb.nameComponents(short)
+
+	// Register before building so recursive types terminate.
+	schema := &Schema{}
+	sb.schemas[sb.names[id]] = schema
+	*schema = *sb.buildExprSchema(decl.Spec.Type, decl, typeArgs)
+	if schema.Description == "" {
+		schema.Description = decl.Doc
+	}
+	applyEnums(schema, decl.Enums)
+
+	return sb.componentRef(id)
+}
+
+// componentRef returns a reference to the component of the type ID and
+// remembers it, so the reference follows the component if it is renamed.
+func (sb *SchemaBuilder) componentRef(id string) *Schema {
+	ref := refSchema(sb.names[id])
+	sb.refs[id] = append(sb.refs[id], ref)
+	return ref
+}
+
+// nameComponents names the components of the types sharing an unqualified
+// name. A name used by a single type stays bare; otherwise all of them are
+// qualified, so the names depend only on which types are referenced and not
+// on the order they are built in. Components built under a previous name are
+// moved along with their references.
+func (sb *SchemaBuilder) nameComponents(short string) {
+	ids := slices.Sorted(slices.Values(sb.short[short]))
+	names := qualifiedNames(short, ids, sb.decls)
+
+	moved := make(map[string]*Schema)
+	for i, id := range ids {
+		if old, ok := sb.names[id]; ok && old != names[i] {
+			moved[id] = sb.schemas[old]
+			delete(sb.schemas, old)
+		}
+		sb.names[id] = names[i]
+	}
+
+	for id, schema := range moved {
+		sb.schemas[sb.names[id]] = schema
+		for _, ref := range sb.refs[id] {
+			ref.Ref = refSchema(sb.names[id]).Ref
+		}
+	}
+}
+
+// qualifiedNames returns the component names for the sorted type IDs sharing
+// an unqualified name: the bare name for a single type, else the name
+// qualified by package name, by import path, or by the full type ID, whichever
+// is the first to tell all of them apart.
+func qualifiedNames(short string, ids []string, decls map[string]*parser.TypeDecl) []string {
+	if len(ids) == 1 {
+		return []string{short}
+	}
+
+	qualifiers := []func(id string) string{
+		func(id string) string { return decls[id].PkgName + "." + short },
+		func(id string) string { return componentSafe(decls[id].PkgPath) + "." + short },
+		componentSafe,
+	}
+
+	var names []string
+	for _, qualify := range qualifiers {
+		names = make([]string, len(ids))
+		seen := make(map[string]bool, len(ids))
+		for i, id := range ids {
+			names[i] = qualify(id)
+			seen[names[i]] = true
+		}
+		if len(seen) == len(ids) {
+			break
+		}
+	}
+	return names
+}
+
+// componentSafe maps s onto the characters allowed in component names,
+// turning path separators into dots and anything else into underscores.
+func componentSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '/':
+			return '.'
+		case r == '.' || r == '-' || r == '_',
+			r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// applyEnums lists the constants declared with a named basic type as the
+// enum values of its schema, with their names in x-enum-varnames and their
+// comments in x-enum-descriptions.
+func applyEnums(schema *Schema, enums []parser.EnumValue) {
+	if len(enums) == 0 {
+		return
+	}
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+	default:
+		return
+	}
+
+	var described bool
+	for _, enum := range enums {
+		schema.Enum = append(schema.Enum, enum.Value)
+		schema.EnumVarNames = append(schema.EnumVarNames, enum.Name)
+		schema.EnumDescriptions = append(schema.EnumDescriptions, enum.Comment)
+		described = described || enum.Comment != ""
+	}
+
+	if !described {
+		schema.EnumDescriptions = nil
+	}
+}
+
+// bindTypeArgs maps the type parameters of a generic declaration to the
+// given type arguments. It returns nil if the counts do not match.
+func bindTypeArgs(decl *parser.TypeDecl, args []string) map[string]string {
+	params := decl.TypeParams()
+	if len(params) == 0 || len(params) != len(args) {
+		return nil
+	}
+
+	typeArgs := make(map[string]string, len(params))
+	for i, param := range params {
+		typeArgs[param] = args[i]
+	}
+	return typeArgs
+}
+
+// componentName returns the unqualified component name for a declaration.
+// Generic instantiations append their type arguments, as in Result_User.
+func componentName(decl *parser.TypeDecl, args []string) string {
+	name := decl.Name
+	for _, arg := range args {
+		name += "_" + typeArgName(arg)
+	}
+	return name
+}
+
+// typeArgName returns the component-name form of a type argument: package
+// paths are dropped and slices, maps and nested instantiations are spelled
+// out, so []model.User becomes array_User and Page[User] becomes Page_User.
+func typeArgName(arg string) string {
+	arg = strings.TrimPrefix(strings.TrimSpace(arg), "*")
+
+	if strings.HasPrefix(arg, "[]") {
+		return "array_" + typeArgName(arg[2:])
+	}
+
+	if strings.HasPrefix(arg, "map[") {
+		if end := strings.Index(arg, "]"); end != -1 {
+			return "map_" + typeArgName(arg[len("map["):end]) + "_" + typeArgName(arg[end+1:])
+		}
+	}
+
+	base, args := parser.SplitTypeArgs(arg)
+	name := base[strings.LastIndex(base, ".")+1:]
+	for _, a := range args {
+		name += "_" + typeArgName(a)
+	}
+	return name
+}
+
+// buildExprSchema builds a schema from a type expression found in the
+// declaration's source file. typeArgs maps the declaration's type
+// parameters to the type arguments it is instantiated with.
+func (sb *SchemaBuilder) buildExprSchema(expr ast.Expr, decl *parser.TypeDecl, typeArgs map[string]string) *Schema {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return sb.buildExprSchema(t.X, decl, typeArgs)
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{
+			Type:  "array",
+			Items: sb.buildExprSchema(t.Elt, decl, typeArgs),
+		}
+	case *ast.MapType:
 		return &Schema{
-			Ref: fmt.Sprintf("#/components/schemas/%s", typeStr),
+			Type:                 "object",
+			AdditionalProperties: sb.buildExprSchema(t.Value, decl, typeArgs),
+		}
+	case *ast.InterfaceType:
+		return &Schema{}
+	case *ast.StructType:
+		return sb.buildStructType(t, decl, typeArgs)
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return sb.buildSchemaFromType(sb.exprTypeName(t, decl, typeArgs))
+	case *ast.Ident:
+		if arg, ok := typeArgs[t.Name]; ok {
+			return sb.buildSchemaFromType(arg)
+		}
+		if schema := basicSchema(t.Name); schema != nil {
+			return schema
+		}
+		return sb.resolveRef(t.Name, decl)
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			name := x.Name + "." + t.Sel.Name
+			if schema := basicSchema(name); schema != nil {
+				return schema
+			}
+			return sb.resolveRef(name, decl)
+		}
+	}
+
+	return &Schema{Type: "object"}
+}
+
+// resolveRef resolves a type name in the context of a declaration and
+// returns a reference to its component.
+func (sb *SchemaBuilder) resolveRef(name string, decl *parser.TypeDecl) *Schema {
+	if sb.types != nil {
+		if target := sb.types.ResolveName(name, decl.File, decl.PkgPath); target != nil {
+			return sb.buildDeclRef(target, nil)
 		}
 	}
+
+	// Types outside the module have no source to build from.
+	return &Schema{Type: "object"}
+}
+
+// exprTypeName returns the type expression as a type string in which type
+// parameters are replaced by their arguments and module types by their
+// fully-qualified IDs, as expected by buildSchemaFromType.
+func (sb *SchemaBuilder) exprTypeName(expr ast.Expr, decl *parser.TypeDecl, typeArgs map[string]string) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return sb.exprTypeName(t.X, decl, typeArgs)
+	case *ast.ArrayType:
+		return "[]" + sb.exprTypeName(t.Elt, decl, typeArgs)
+	case *ast.MapType:
+		return "map[" + sb.exprTypeName(t.Key, decl, typeArgs) + "]" + sb.exprTypeName(t.Value, decl, typeArgs)
+	case *ast.IndexExpr:
+		return sb.exprTypeName(t.X, decl, typeArgs) + "[" + sb.exprTypeName(t.Index, decl, typeArgs) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = sb.exprTypeName(index, decl, typeArgs)
+		}
+		return sb.exprTypeName(t.X, decl, typeArgs) + "[" + strings.Join(args, ",") + "]"
+	case *ast.Ident:
+		if arg, ok := typeArgs[t.Name]; ok {
+			return arg
+		}
+		return sb.qualifiedName(t.Name, decl)
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			return sb.qualifiedName(x.Name+"."+t.Sel.Name, decl)
+		}
+	}
+
+	return "interface{}"
 }
 
-// BuildStructSchema builds a schema from a struct definition.
-func (sb *SchemaBuilder) BuildStructSchema(name string, fields map[string]string) *Schema {
+// qualifiedName returns the fully-qualified ID of a type name used in the
+// declaration's source file, or the name itself for built-in and
+// unresolvable types.
+func (sb *SchemaBuilder) qualifiedName(name string, decl *parser.TypeDecl) string {
+	if basicSchema(name) != nil || sb.types == nil {
+		return name
+	}
+	if target := sb.types.ResolveName(name, decl.File, decl.PkgPath); target != nil {
+		return target.ID()
+	}
+	return name
+}
+
+// StructField describes a struct field as it appears in a schema.
+type StructField struct {
+	Name     string
+	Schema   *Schema
+	Required bool
+}
+
+// BuildStructFields returns the fields of the struct type with the given
+// fully-qualified ID, named after the first of tagKeys present on each field.
+// It returns nil if the type cannot be resolved to a struct.
+func (sb *SchemaBuilder) BuildStructFields(id string, tagKeys ...string) []StructField {
+	if sb.types == nil {
+		return nil
+	}
+
+	base, args := parser.SplitTypeArgs(id)
+	decl := sb.types.Lookup(base)
+	if decl == nil {
+		return nil
+	}
+
+	st, ok := decl.Spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+
+	return sb.structFields(st, decl, bindTypeArgs(decl, args), tagKeys)
+}
+
+// buildStructType builds an object schema from a struct type expression.
+// In allOf mode, structs that embed other structs become an allOf of the
+// embedded components and an object holding the remaining fields.
+func (sb *SchemaBuilder) buildStructType(st *ast.StructType, decl *parser.TypeDecl, typeArgs map[string]string) *Schema {
+	if sb.allOfEmb {
+		if schema := sb.buildAllOfStruct(st, decl, typeArgs); schema != nil {
+			return schema
+		}
+	}
+
+	return objectSchema(sb.structFields(st, decl, typeArgs, bodyTagKeys))
+}
+
+// objectSchema builds an object schema from struct fields.
+func objectSchema(fields []StructField) *Schema {
 	schema := &Schema{
 		Type:       "object",
 		Properties: make(map[string]*Schema),
 		Required:   make([]string, 0),
 	}
 
-	for fieldName, fieldType := range fields {
-		fieldSchema := sb.buildSchemaFromType(fieldType)
-		schema.Properties[fieldName] = fieldSchema
+	for _, field := range fields {
+		schema.Properties[field.Name] = field.Schema
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
 
-		// Mark as required if not a pointer type
-		if !strings.HasPrefix(fieldType, "*") {
-			schema.Required = append(schema.Required, fieldName)
+	return schema
+}
+
+// buildAllOfStruct composes a struct from references to its embedded
+// structs and its own fields. It returns nil if nothing is embedded.
+func (sb *SchemaBuilder) buildAllOfStruct(st *ast.StructType, decl *parser.TypeDecl, typeArgs map[string]string) *Schema {
+	var refs []*Schema
+	var own []fieldCandidate
+
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			if target, args := sb.embeddedStruct(field, decl, typeArgs, bodyTagKeys); target != nil {
+				refs = append(refs, sb.buildDeclRef(target, args))
+				continue
+			}
+		}
+		own = append(own, sb.collectField(field, decl, typeArgs, bodyTagKeys, 0, nil)...)
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	schema := &Schema{AllOf: refs}
+	if fields := dominantFields(own); len(fields) > 0 {
+		schema.AllOf = append(schema.AllOf, objectSchema(fields))
+	}
+	return schema
+}
+
+// fieldCandidate is a struct field together with the information
+// encoding/json uses to pick between fields of the same name.
+type fieldCandidate struct {
+	StructField
+	depth  int  // embedding depth, 0 for fields declared on the struct itself
+	tagged bool // the name comes from a struct tag
+}
+
+// structFields returns the wire-visible fields of a struct type expression.
+// Unexported fields and fields tagged "-" are skipped; pointer and omitempty
+// fields are optional. Fields of embedded structs without a tag name are
+// promoted into the parent, as encoding/json does.
+func (sb *SchemaBuilder) structFields(st *ast.StructType, decl *parser.TypeDecl, typeArgs map[string]string, tagKeys []string) []StructField {
+	visited := map[string]bool{decl.ID(): true}
+
+	var candidates []fieldCandidate
+	for _, field := range st.Fields.List {
+		candidates = append(candidates, sb.collectField(field, decl, typeArgs, tagKeys, 0, visited)...)
+	}
+
+	return dominantFields(candidates)
+}
+
+// collectField returns the candidates contributed by one field
+// declaration: one per name, or the promoted fields of an embedded struct.
+// visited holds the structs being flattened, so embedding cycles through
+// pointers terminate; nil disables flattening.
+func (sb *SchemaBuilder) collectField(field *ast.Field, decl *parser.TypeDecl, typeArgs map[string]string, tagKeys []string, depth int, visited map[string]bool) []fieldCandidate {
+	if len(field.Names) == 0 {
+		return sb.embeddedFields(field, decl, typeArgs, tagKeys, depth, visited)
+	}
+
+	var candidates []fieldCandidate
+	for _, name := range field.Names {
+		if !name.IsExported() {
+			continue
+		}
+
+		tag := parseFieldTag(name.Name, astFieldTag(field), tagKeys...)
+		if tag.Skip {
+			continue
+		}
+		candidates = append(candidates, sb.fieldCandidate(field, tag, tagKeys, decl, typeArgs, depth))
+	}
+	return candidates
+}
+
+// embeddedFields returns the fields contributed by an embedded field. An
+// embedded struct without a tag name is flattened; anything else becomes a
+// single field named after its tag or its type.
+func (sb *SchemaBuilder) embeddedFields(field *ast.Field, decl *parser.TypeDecl, typeArgs map[string]string, tagKeys []string, depth int, visited map[string]bool) []fieldCandidate {
+	name := embeddedName(field.Type)
+	tag := parseFieldTag(name, astFieldTag(field), tagKeys...)
+	if tag.Skip {
+		return nil
+	}
+
+	if visited != nil {
+		if target, args := sb.embeddedStruct(field, decl, typeArgs, tagKeys); target != nil {
+			if visited[target.ID()] {
+				return nil
+			}
+
+			inner := make(map[string]bool, len(visited)+1)
+			for id := range visited {
+				inner[id] = true
+			}
+			inner[target.ID()] = true
+
+			st := target.Spec.Type.(*ast.StructType)
+			innerArgs := bindTypeArgs(target, args)
+
+			var promoted []fieldCandidate
+			for _, f := range st.Fields.List {
+				promoted = append(promoted, sb.collectField(f, target, innerArgs, tagKeys, depth+1, inner)...)
+			}
+
+			// encoding/json omits the fields of a nil embedded pointer.
+			if _, isPointer := field.Type.(*ast.StarExpr); isPointer {
+				for i := range promoted {
+					promoted[i].Required = false
+				}
+			}
+			return promoted
+		}
+	}
+
+	if !ast.IsExported(name) {
+		return nil
+	}
+	return []fieldCandidate{sb.fieldCandidate(field, tag, tagKeys, decl, typeArgs, depth)}
+}
+
+// embeddedStruct resolves an embedded field without a tag name to the
+// struct declaration it embeds, along with its type arguments. It returns
+// nil for named fields and for embedded types that are not module structs.
+func (sb *SchemaBuilder) embeddedStruct(field *ast.Field, decl *parser.TypeDecl, typeArgs map[string]string, tagKeys []string) (*parser.TypeDecl, []string) {
+	if sb.types == nil {
+		return nil, nil
+	}
+
+	tag := parseFieldTag(embeddedName(field.Type), astFieldTag(field), tagKeys...)
+	if tag.Named || tag.Skip {
+		return nil, nil
+	}
+
+	base, args := parser.SplitTypeArgs(sb.exprTypeName(field.Type, decl, typeArgs))
+	target := sb.types.Lookup(base)
+	if target == nil {
+		return nil, nil
+	}
+	if _, ok := target.Spec.Type.(*ast.StructType); !ok {
+		return nil, nil
+	}
+	return target, args
+}
+
+// fieldCandidate builds the schema of a named struct field. Body fields are
+// required unless they are pointers or omitempty; form and query tags have
+// no omitempty, so those fields are required only when validation says so.
+func (sb *SchemaBuilder) fieldCandidate(field *ast.Field, tag fieldTag, tagKeys []string, decl *parser.TypeDecl, typeArgs map[string]string, depth int) fieldCandidate {
+	fieldSchema := sb.buildExprSchema(field.Type, decl, typeArgs)
+	if tag.AsString {
+		fieldSchema = stringEncoded(fieldSchema)
+	}
+	if fieldSchema.Ref == "" {
+		fieldSchema.Description = fieldDescription(field)
+	}
+
+	validated := applyValidation(fieldSchema, tag.Tag)
+
+	required := validated
+	if !slices.Equal(tagKeys, formTagKeys) {
+		_, isPointer := field.Type.(*ast.StarExpr)
+		required = required || (!isPointer && !tag.OmitEmpty)
+	}
+
+	return fieldCandidate{
+		StructField: StructField{
+			Name:     tag.Name,
+			Schema:   fieldSchema,
+			Required: required,
+		},
+		depth:  depth,
+		tagged: tag.Named,
+	}
+}
+
+// embeddedName returns the field name Go gives an embedded type: the type
+// name without package qualifier, pointer or type arguments.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(t.X)
+	case *ast.IndexListExpr:
+		return embeddedName(t.X)
+	}
+	return ""
+}
+
+// dominantFields resolves fields that share a name the way encoding/json
+// does: the shallowest field wins, a tagged field beats untagged ones at the
+// same depth, and otherwise the name is dropped. Fields keep the order in
+// which their names first appear.
+func dominantFields(candidates []fieldCandidate) []StructField {
+	var order []string
+	byName := make(map[string][]fieldCandidate)
+	for _, c := range candidates {
+		if _, seen := byName[c.Name]; !seen {
+			order = append(order, c.Name)
+		}
+		byName[c.Name] = append(byName[c.Name], c)
+	}
+
+	fields := make([]StructField, 0, len(order))
+	for _, name := range order {
+		if field, ok := dominantField(byName[name]); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// dominantField picks the field that wins among fields with the same name.
+func dominantField(candidates []fieldCandidate) (StructField, bool) {
+	depth := candidates[0].depth
+	for _, c := range candidates[1:] {
+		if c.depth < depth {
+			depth = c.depth
+		}
+	}
+
+	var shallowest, tagged []fieldCandidate
+	for _, c := range candidates {
+		if c.depth != depth {
+			continue
+		}
+		shallowest = append(shallowest, c)
+		if c.tagged {
+			tagged = append(tagged, c)
+		}
+	}
+
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0].StructField, true
+	case len(tagged) == 1:
+		return tagged[0].StructField, true
+	default:
+		return StructField{}, false
+	}
+}
+
+// fieldDescription returns the doc or trailing comment of a struct field.
+func fieldDescription(field *ast.Field) string {
+	if field.Doc != nil {
+		return strings.TrimSpace(field.Doc.Text())
+	}
+	if field.Comment != nil {
+		return strings.TrimSpace(field.Comment.Text())
+	}
+	return ""
+}
+
+// refSchema returns a reference to a component schema.
+func refSchema(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// FieldDef describes a struct field for BuildStructSchema.
+type FieldDef struct {
+	Name string            // Go field name
+	Type string            // Go type, as accepted by BuildSchema
+	Tag  reflect.StructTag // struct tag, such as `json:"id,omitempty"`
+}
+
+// BuildStructSchema builds an object schema from struct field definitions
+// and registers it under name. As in BuildFromReflect, fields are named and
+// skipped by their json or yaml tags, unexported fields are skipped, and
+// pointer or omitempty fields are optional unless validation requires them.
+func (sb *SchemaBuilder) BuildStructSchema(name string, fields []FieldDef) *Schema {
+	candidates := make([]fieldCandidate, 0, len(fields))
+	for _, field := range fields {
+		if !ast.IsExported(field.Name) {
+			continue
+		}
+
+		tag := parseFieldTag(field.Name, field.Tag, bodyTagKeys...)
+		if tag.Skip {
+			continue
+		}
+
+		fieldSchema := sb.buildSchemaFromType(field.Type)
+		if tag.AsString {
+			fieldSchema = stringEncoded(fieldSchema)
 		}
+		validated := applyValidation(fieldSchema, tag.Tag)
+
+		candidates = append(candidates, fieldCandidate{
+			StructField: StructField{
+				Name:     tag.Name,
+				Schema:   fieldSchema,
+				Required: validated || (!strings.HasPrefix(field.Type, "*") && !tag.OmitEmpty),
+			},
+			tagged: tag.Named,
+		})
 	}
 
+	schema := objectSchema(dominantFields(candidates))
 	sb.schemas[name] = schema
 	return schema
 }
@@ -139,28 +835,7 @@ func (sb *SchemaBuilder) BuildFromReflect(t reflect.Type) *Schema {
 
 	// Handle struct types
 	if t.Kind() == reflect.Struct {
-		schema := &Schema{
-			Type:       "object",
-			Properties: make(map[string]*Schema),
-			Required:   make([]string, 0),
-		}
-
-		for i := 0; i < t.NumField(); i++ {
-			field := t.Field(i)
-			if field.Anonymous {
-				continue
-			}
-
-			fieldSchema := sb.BuildFromReflect(field.Type)
-			schema.Properties[field.Name] = fieldSchema
-
-			// Check if field is required (not a pointer)
-			if field.Type.Kind() != reflect.Ptr {
-				schema.Required = append(schema.Required, field.Name)
-			}
-		}
-
-		return schema
+		return objectSchema(dominantFields(sb.reflectFields(t, 0, map[reflect.Type]bool{t: true})))
 	}
 
 	// Handle basic types
@@ -181,3 +856,67 @@ func (sb *SchemaBuilder) BuildFromReflect(t reflect.Type) *Schema {
 		return &Schema{}
 	}
 }
+
+// reflectFields returns the field candidates of a struct type. Embedded
+// structs without a tag name are flattened, as encoding/json does; visited
+// holds the structs being flattened so pointer cycles terminate.
+func (sb *SchemaBuilder) reflectFields(t reflect.Type, depth int, visited map[reflect.Type]bool) []fieldCandidate {
+	var candidates []fieldCandidate
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := parseFieldTag(field.Name, field.Tag, bodyTagKeys...)
+		if tag.Skip {
+			continue
+		}
+
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			if !tag.Named && embedded.Kind() == reflect.Struct {
+				if visited[embedded] {
+					continue
+				}
+				visited[embedded] = true
+				promoted := sb.reflectFields(embedded, depth+1, visited)
+				delete(visited, embedded)
+
+				// encoding/json omits the fields of a nil embedded pointer.
+				if field.Type.Kind() == reflect.Ptr {
+					for j := range promoted {
+						promoted[j].Required = false
+					}
+				}
+				candidates = append(candidates, promoted...)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldSchema := sb.BuildFromReflect(field.Type)
+		if tag.AsString {
+			fieldSchema = stringEncoded(fieldSchema)
+		}
+		validated := applyValidation(fieldSchema, tag.Tag)
+
+		// Required if validated, or neither a pointer nor omitempty
+		candidates = append(candidates, fieldCandidate{
+			StructField: StructField{
+				Name:     tag.Name,
+				Schema:   fieldSchema,
+				Required: validated || (field.Type.Kind() != reflect.Ptr && !tag.OmitEmpty),
+			},
+			depth:  depth,
+			tagged: tag.Named,
+		})
+	}
+
+	return candidates
+}
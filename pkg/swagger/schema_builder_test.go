@@ -113,10 +113,10 @@ func TestSchemaBuilderBuildSchema_InterfaceType(t *testing.T) {
 func TestSchemaBuilderBuildStructSchema(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	fields := map[string]string{
-		"id":   "int",
-		"name": "string",
-		"age":  "int",
+	fields := []FieldDef{
+		{Name: "ID", Type: "int", Tag: `json:"id"`},
+		{Name: "Name", Type: "string", Tag: `json:"name"`},
+		{Name: "Age", Type: "int", Tag: `json:"age"`},
 	}
 
 	schema := sb.BuildStructSchema("User", fields)
@@ -130,14 +130,34 @@ func TestSchemaBuilderBuildStructSchema(t *testing.T) {
 	assert.Len(t, schema.Required, 3)
 }
 
+func TestSchemaBuilderBuildStructSchema_Tags(t *testing.T) {
+	sb := NewSchemaBuilder()
+
+	schema := sb.BuildStructSchema("User", []FieldDef{
+		{Name: "UserName", Type: "string", Tag: `json:"user_name,omitempty"`},
+		{Name: "Password", Type: "string", Tag: `json:"-"`},
+		{Name: "Count", Type: "int", Tag: `json:"count,string"`},
+		{Name: "Email", Type: "string", Tag: `json:"email,omitempty" validate:"required,email"`},
+		{Name: "Nickname", Type: "string", Tag: `yaml:"nick"`},
+		{Name: "Plain", Type: "bool"},
+		{Name: "secret", Type: "string", Tag: `json:"secret"`},
+	})
+
+	assert.ElementsMatch(t, []string{"user_name", "count", "email", "nick", "Plain"}, keys(schema.Properties))
+	assert.Equal(t, "string", schema.Properties["count"].Type)
+	assert.Equal(t, "email", schema.Properties["email"].Format)
+	// Required fields follow the field order
+	assert.Equal(t, []string{"count", "email", "nick", "Plain"}, schema.Required)
+}
+
 func TestSchemaBuilderBuildStructSchema_WithPointers(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	fields := map[string]string{
-		"id":    "int",
-		"name":  "string",
-		"email": "*string",
-		"phone": "*string",
+	fields := []FieldDef{
+		{Name: "ID", Type: "int", Tag: `json:"id"`},
+		{Name: "Name", Type: "string", Tag: `json:"name"`},
+		{Name: "Email", Type: "*string", Tag: `json:"email"`},
+		{Name: "Phone", Type: "*string", Tag: `json:"phone"`},
 	}
 
 	schema := sb.BuildStructSchema("User", fields)
@@ -154,8 +174,8 @@ func TestSchemaBuilderBuildStructSchema_WithPointers(t *testing.T) {
 func TestSchemaBuilderGetSchemas(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	fields1 := map[string]string{"id": "int", "name": "string"}
-	fields2 := map[string]string{"id": "int", "email": "string"}
+	fields1 := []FieldDef{{Name: "ID", Type: "int", Tag: `json:"id"`}, {Name: "Name", Type: "string", Tag: `json:"name"`}}
+	fields2 := []FieldDef{{Name: "ID", Type: "int", Tag: `json:"id"`}, {Name: "Email", Type: "string", Tag: `json:"email"`}}
 
 	sb.BuildStructSchema("User", fields1)
 	sb.BuildStructSchema("Admin", fields2)
@@ -169,7 +189,7 @@ func TestSchemaBuilderGetSchemas(t *testing.T) {
 func TestSchemaBuilderGetSchema(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	fields := map[string]string{"id": "int", "name": "string"}
+	fields := []FieldDef{{Name: "ID", Type: "int", Tag: `json:"id"`}, {Name: "Name", Type: "string", Tag: `json:"name"`}}
 	sb.BuildStructSchema("User", fields)
 
 	schema := sb.GetSchema("User")
@@ -206,7 +226,7 @@ func TestSchemaBuilderBuildSchema_PointerToArray(t *testing.T) {
 func TestSchemaBuilderBuildStructSchema_Empty(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	schema := sb.BuildStructSchema("Empty", map[string]string{})
+	schema := sb.BuildStructSchema("Empty", []FieldDef{})
 
 	assert.NotNil(t, schema)
 	assert.Equal(t, "object", schema.Type)
@@ -217,12 +237,12 @@ func TestSchemaBuilderBuildStructSchema_Empty(t *testing.T) {
 func TestSchemaBuilderBuildStructSchema_ComplexTypes(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	fields := map[string]string{
-		"id":       "int",
-		"name":     "string",
-		"tags":     "[]string",
-		"metadata": "map[string]interface{}",
-		"user":     "User",
+	fields := []FieldDef{
+		{Name: "ID", Type: "int", Tag: `json:"id"`},
+		{Name: "Name", Type: "string", Tag: `json:"name"`},
+		{Name: "Tags", Type: "[]string", Tag: `json:"tags"`},
+		{Name: "Metadata", Type: "map[string]interface{}", Tag: `json:"metadata"`},
+		{Name: "User", Type: "User", Tag: `json:"user"`},
 	}
 
 	schema := sb.BuildStructSchema("Post", fields)
@@ -245,15 +265,15 @@ func TestSchemaBuilderBuildStructSchema_ComplexTypes(t *testing.T) {
 func TestSchemaBuilderMultipleStructs(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	userFields := map[string]string{
-		"id":   "int",
-		"name": "string",
+	userFields := []FieldDef{
+		{Name: "ID", Type: "int", Tag: `json:"id"`},
+		{Name: "Name", Type: "string", Tag: `json:"name"`},
 	}
 
-	postFields := map[string]string{
-		"id":     "int",
-		"title":  "string",
-		"author": "User",
+	postFields := []FieldDef{
+		{Name: "ID", Type: "int", Tag: `json:"id"`},
+		{Name: "Title", Type: "string", Tag: `json:"title"`},
+		{Name: "Author", Type: "User", Tag: `json:"author"`},
 	}
 
 	sb.BuildStructSchema("User", userFields)
@@ -413,6 +433,55 @@ func TestSchemaBuilderBuildFromReflect_StructWithPointers(t *testing.T) {
 	assert.Contains(t, schema.Required, "Name")
 }
 
+func TestSchemaBuilderBuildFromReflect_StructTags(t *testing.T) {
+	sb := NewSchemaBuilder()
+
+	type User struct {
+		ID       int64   `json:"id,string"`
+		UserName string  `json:"user_name"`
+		Nickname string  `json:"nickname,omitempty"`
+		Email    *string `json:"email"`
+		Password string  `json:"-"`
+		Version  int     `yaml:"version"`
+		internal string
+	}
+
+	schema := sb.BuildFromReflect(reflect.TypeOf(User{}))
+
+	assert.Len(t, schema.Properties, 5)
+	assert.Equal(t, "string", schema.Properties["id"].Type)
+	assert.NotNil(t, schema.Properties["user_name"])
+	assert.NotNil(t, schema.Properties["nickname"])
+	assert.NotNil(t, schema.Properties["email"])
+	assert.NotNil(t, schema.Properties["version"])
+	assert.NotContains(t, schema.Properties, "Password")
+	assert.NotContains(t, schema.Properties, "internal")
+	assert.ElementsMatch(t, []string{"id", "user_name", "version"}, schema.Required)
+}
+
+func TestSchemaBuilderBuildFromReflect_ValidationTags(t *testing.T) {
+	sb := NewSchemaBuilder()
+
+	type ListRequest struct {
+		Keyword string   `json:"keyword,omitempty" binding:"required,min=1,max=100"`
+		Email   *string  `json:"email" validate:"omitempty,email"`
+		Sort    string   `json:"sort,omitempty" binding:"oneof=asc desc"`
+		Size    int      `json:"size" binding:"gte=1,lte=50"`
+		IDs     []string `json:"ids,omitempty" binding:"dive,uuid"`
+	}
+
+	schema := sb.BuildFromReflect(reflect.TypeOf(ListRequest{}))
+
+	assert.ElementsMatch(t, []string{"keyword", "size"}, schema.Required)
+	assert.Equal(t, 1, *schema.Properties["keyword"].MinLength)
+	assert.Equal(t, 100, *schema.Properties["keyword"].MaxLength)
+	assert.Equal(t, "email", schema.Properties["email"].Format)
+	assert.Equal(t, []interface{}{"asc", "desc"}, schema.Properties["sort"].Enum)
+	assert.Equal(t, 1.0, *schema.Properties["size"].Minimum)
+	assert.Equal(t, 50.0, *schema.Properties["size"].Maximum)
+	assert.Equal(t, "uuid", schema.Properties["ids"].Items.Format)
+}
+
 func TestSchemaBuilderBuildFromReflect_Nil(t *testing.T) {
 	sb := NewSchemaBuilder()
 
@@ -511,15 +580,15 @@ func TestSchemaBuilderBuildSchema_ComplexNesting(t *testing.T) {
 func TestSchemaBuilderBuildStructSchema_MixedTypes(t *testing.T) {
 	sb := NewSchemaBuilder()
 
-	fields := map[string]string{
-		"id":        "int64",
-		"name":      "string",
-		"active":    "bool",
-		"score":     "float64",
-		"tags":      "[]string",
-		"metadata":  "map[string]interface{}",
-		"createdAt": "time.Time",
-		"updatedAt": "*time.Time",
+	fields := []FieldDef{
+		{Name: "ID", Type: "int64", Tag: `json:"id"`},
+		{Name: "Name", Type: "string", Tag: `json:"name"`},
+		{Name: "Active", Type: "bool", Tag: `json:"active"`},
+		{Name: "Score", Type: "float64", Tag: `json:"score"`},
+		{Name: "Tags", Type: "[]string", Tag: `json:"tags"`},
+		{Name: "Metadata", Type: "map[string]interface{}", Tag: `json:"metadata"`},
+		{Name: "CreatedAt", Type: "time.Time", Tag: `json:"createdAt"`},
+		{Name: "UpdatedAt", Type: "*time.Time", Tag: `json:"updatedAt"`},
 	}
 
 	schema := sb.BuildStructSchema("ComplexModel", fields)
@@ -583,3 +652,61 @@ func TestSchemaBuilderBuildSchema_AllBasicTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaBuilderBuildSchema_GenericTypes(t *testing.T) {
+	sb := NewSchemaBuilder()
+
+	assert.Equal(t, "#/components/schemas/Result_User", sb.BuildSchema("Result[User]").Ref)
+	assert.Equal(t, "#/components/schemas/Page_array_User", sb.BuildSchema("*model.Page[[]model.User]").Ref)
+	assert.Equal(t, "#/components/schemas/Pair_string_Result_User", sb.BuildSchema("Pair[string,Result[User]]").Ref)
+}
+
+func TestSchemaBuilderBuildFromReflect_EmbeddedStructs(t *testing.T) {
+	type Base struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	type Left struct {
+		Code string `json:"code"`
+	}
+	type Right struct {
+		Code string `json:"code"`
+	}
+	type Meta struct {
+		Version int `json:"version"`
+	}
+	type Model struct {
+		Base
+		*Left
+		Right
+		Meta  `json:"meta"`
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	}
+
+	sb := NewSchemaBuilder()
+	schema := sb.BuildFromReflect(reflect.TypeOf(Model{}))
+
+	assert.Equal(t, "object", schema.Type)
+	// The outer name shadows Base.Name and the ambiguous code fields are dropped
+	assert.Len(t, schema.Properties, 4)
+	assert.Equal(t, "integer", schema.Properties["id"].Type)
+	assert.Equal(t, "string", schema.Properties["name"].Type)
+	assert.Equal(t, "object", schema.Properties["meta"].Type)
+	assert.Contains(t, schema.Properties["meta"].Properties, "version")
+	assert.NotContains(t, schema.Properties, "code")
+	assert.ElementsMatch(t, []string{"id", "name", "meta", "title"}, schema.Required)
+}
+
+func TestSchemaBuilderBuildFromReflect_EmbeddedPointerCycle(t *testing.T) {
+	type Node struct {
+		*Node
+		Value string `json:"value"`
+	}
+
+	sb := NewSchemaBuilder()
+	schema := sb.BuildFromReflect(reflect.TypeOf(Node{}))
+
+	assert.Len(t, schema.Properties, 1)
+	assert.Equal(t, "string", schema.Properties["value"].Type)
+}
@@ -0,0 +1,154 @@
+package swagger
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TagOrder selects how the tags of the document are ordered.
+type TagOrder string
+
+const (
+	// TagOrderDeclared lists the tags declared with @tag.name first, in
+	// declaration order, followed by the other tags in order of first use.
+	TagOrderDeclared TagOrder = "declared"
+	// TagOrderAlpha sorts the tags by name.
+	TagOrderAlpha TagOrder = "alpha"
+)
+
+// ungroupedTags names the x-tagGroups entry holding the tags that belong to
+// no group, since ReDoc hides tags missing from every group.
+const ungroupedTags = "Other"
+
+// ParseTagOrder parses a tag order name. An empty name selects TagOrderDeclared.
+func ParseTagOrder(name string) (TagOrder, error) {
+	switch TagOrder(name) {
+	case "", TagOrderDeclared:
+		return TagOrderDeclared, nil
+	case TagOrderAlpha:
+		return TagOrderAlpha, nil
+	default:
+		return "", fmt.Errorf("invalid tag order %q, expected declared or alpha", name)
+	}
+}
+
+// ParseTagGroup parses a tag group written as "Name=Tag1,Tag2".
+func ParseTagGroup(spec string) (TagGroup, error) {
+	name, list, ok := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return TagGroup{}, fmt.Errorf("invalid tag group %q, expected Name=Tag1,Tag2", spec)
+	}
+
+	group := TagGroup{Name: name}
+	for _, tag := range strings.Split(list, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			group.Tags = append(group.Tags, tag)
+		}
+	}
+	if len(group.Tags) == 0 {
+		return TagGroup{}, fmt.Errorf("tag group %q has no tags", name)
+	}
+
+	return group, nil
+}
+
+// SetTagOrder sets how the tags of the document are ordered.
+func (b *Builder) SetTagOrder(order TagOrder) {
+	b.tagOrder = order
+	b.syncTags()
+}
+
+// AddTagGroup adds tags to an x-tagGroups entry, creating it if needed. Once
+// any group exists, the tags outside every group are listed under "Other".
+func (b *Builder) AddTagGroup(name string, tags ...string) {
+	i := 0
+	for i < len(b.tagGroups) && b.tagGroups[i].Name != name {
+		i++
+	}
+	if i == len(b.tagGroups) {
+		b.tagGroups = append(b.tagGroups, TagGroup{Name: name})
+	}
+
+	for _, tag := range tags {
+		if !slices.Contains(b.tagGroups[i].Tags, tag) {
+			b.tagGroups[i].Tags = append(b.tagGroups[i].Tags, tag)
+		}
+	}
+
+	b.syncTags()
+}
+
+// addTag adds a tag to the document. Declared tags come from the general API
+// info and fill in the description and external docs of an existing tag.
+func (b *Builder) addTag(tag Tag, declared bool) {
+	if _, ok := b.tagSeq[tag.Name]; !ok {
+		b.tagSeq[tag.Name] = len(b.tagSeq)
+		b.doc.Tags = append(b.doc.Tags, Tag{Name: tag.Name})
+	}
+
+	if declared {
+		if _, ok := b.tagDecl[tag.Name]; !ok {
+			b.tagDecl[tag.Name] = len(b.tagDecl)
+		}
+		for i := range b.doc.Tags {
+			if b.doc.Tags[i].Name == tag.Name {
+				b.doc.Tags[i] = tag
+			}
+		}
+	}
+
+	b.syncTags()
+}
+
+// syncTags orders the document tags and rebuilds x-tagGroups.
+func (b *Builder) syncTags() {
+	sort.SliceStable(b.doc.Tags, func(i, j int) bool {
+		return b.tagLess(b.doc.Tags[i].Name, b.doc.Tags[j].Name)
+	})
+
+	if len(b.tagGroups) == 0 {
+		b.doc.TagGroups = nil
+		return
+	}
+
+	grouped := make(map[string]bool)
+	groups := make([]TagGroup, 0, len(b.tagGroups)+1)
+	for _, group := range b.tagGroups {
+		groups = append(groups, TagGroup{Name: group.Name, Tags: append([]string(nil), group.Tags...)})
+		for _, tag := range group.Tags {
+			grouped[tag] = true
+		}
+	}
+
+	var other []string
+	for _, tag := range b.doc.Tags {
+		if !grouped[tag.Name] {
+			other = append(other, tag.Name)
+		}
+	}
+	if len(other) > 0 {
+		groups = append(groups, TagGroup{Name: ungroupedTags, Tags: other})
+	}
+
+	b.doc.TagGroups = groups
+}
+
+// tagLess reports whether tag x is listed before tag y.
+func (b *Builder) tagLess(x, y string) bool {
+	if b.tagOrder == TagOrderAlpha {
+		return x < y
+	}
+	return b.tagRank(x) < b.tagRank(y)
+}
+
+// tagRank returns the position of a tag in declared order: declared tags
+// first, then the other tags in order of first use.
+func (b *Builder) tagRank(name string) int {
+	if i, ok := b.tagDecl[name]; ok {
+		return i
+	}
+	return len(b.tagDecl) + b.tagSeq[name]
+}
@@ -0,0 +1,81 @@
+package swagger
+
+import (
+	"testing"
+
+	"github.com/neglet30/swag-gen/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestBuilderDeclaredTags(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	require.NoError(t, builder.AddEndpoint(&parser.Endpoint{Method: "GET", Path: "/orders", Tags: []string{"Order", "Audit"}}))
+	builder.SetGeneralInfo(&parser.GeneralInfo{
+		Tags: []parser.TagInfo{
+			{Name: "User", Description: "用户管理", ExternalDocs: parser.ExternalDocs{URL: "https://example.com/user"}},
+			{Name: "Order", Description: "订单"},
+		},
+	})
+	require.NoError(t, builder.AddEndpoint(&parser.Endpoint{Method: "GET", Path: "/users", Tags: []string{"User", "Admin"}}))
+
+	tags := builder.doc.Tags
+	assert.Equal(t, []string{"User", "Order", "Audit", "Admin"}, tagNames(tags))
+	assert.Equal(t, "用户管理", tags[0].Description)
+	require.NotNil(t, tags[0].ExternalDocs)
+	assert.Equal(t, "https://example.com/user", tags[0].ExternalDocs.URL)
+	assert.Equal(t, "订单", tags[1].Description)
+	assert.Nil(t, tags[1].ExternalDocs)
+
+	builder.SetTagOrder(TagOrderAlpha)
+	assert.Equal(t, []string{"Admin", "Audit", "Order", "User"}, tagNames(builder.doc.Tags))
+}
+
+func TestBuilderTagGroups(t *testing.T) {
+	builder := NewBuilder("Test API", "1.0.0", "")
+
+	require.NoError(t, builder.AddEndpoint(&parser.Endpoint{Method: "GET", Path: "/users", Tags: []string{"User", "Health"}}))
+	assert.Nil(t, builder.doc.TagGroups)
+
+	builder.SetGeneralInfo(&parser.GeneralInfo{
+		Tags: []parser.TagInfo{{Name: "User", Group: "账户"}},
+	})
+	builder.AddTagGroup("账户", "Admin", "User")
+	builder.AddTagGroup("订单", "Order")
+
+	assert.Equal(t, []TagGroup{
+		{Name: "账户", Tags: []string{"User", "Admin"}},
+		{Name: "订单", Tags: []string{"Order"}},
+		{Name: "Other", Tags: []string{"Health"}},
+	}, builder.doc.TagGroups)
+
+	data, err := builder.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"x-tagGroups"`)
+}
+
+func TestParseTagGroup(t *testing.T) {
+	group, err := ParseTagGroup("账户 = User, Admin")
+	require.NoError(t, err)
+	assert.Equal(t, TagGroup{Name: "账户", Tags: []string{"User", "Admin"}}, group)
+
+	for _, spec := range []string{"User,Admin", "=User", "账户="} {
+		_, err := ParseTagGroup(spec)
+		assert.Error(t, err, spec)
+	}
+
+	order, err := ParseTagOrder("")
+	require.NoError(t, err)
+	assert.Equal(t, TagOrderDeclared, order)
+	_, err = ParseTagOrder("random")
+	assert.Error(t, err)
+}
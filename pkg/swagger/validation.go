@@ -0,0 +1,213 @@
+package swagger
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tag keys holding gin binding and go-playground validator rules.
+var validationTagKeys = []string{"binding", "validate"}
+
+// oneofPattern splits a oneof rule into values, honoring single quotes.
+var oneofPattern = regexp.MustCompile(`'[^']*'|\S+`)
+
+// Patterns for validator rules that have no dedicated OpenAPI keyword.
+var validationPatterns = map[string]string{
+	"alpha":        `^[a-zA-Z]+$`,
+	"alphanum":     `^[a-zA-Z0-9]+$`,
+	"alphaunicode": `^[\p{L}]+$`,
+	"numeric":      `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":       `^[0-9]+$`,
+	"hexadecimal":  `^(0[xX])?[0-9a-fA-F]+$`,
+	"hexcolor":     `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`,
+	"lowercase":    `^[^A-Z]*$`,
+	"uppercase":    `^[^a-z]*$`,
+}
+
+// Formats for validator rules that map onto OpenAPI string formats.
+var validationFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"http_url": "uri",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ip4_addr": "ipv4",
+	"ipv6":     "ipv6",
+	"ip6_addr": "ipv6",
+	"hostname": "hostname",
+	"datetime": "date-time",
+	"base64":   "byte",
+}
+
+// applyValidation maps the binding and validate rules of a struct tag onto
+// schema constraints and reports whether the field is required. Rules after
+// "dive" apply to the items of an array or the values of a map. References to
+// components only honor "required", since OpenAPI 3.0 ignores siblings of $ref.
+func applyValidation(schema *Schema, tag reflect.StructTag) bool {
+	required := false
+
+	for _, key := range validationTagKeys {
+		rules, ok := tag.Lookup(key)
+		if !ok || rules == "" || rules == "-" {
+			continue
+		}
+
+		target := schema
+		for _, rule := range strings.Split(rules, ",") {
+			name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+			if name == "required" && target == schema {
+				required = true
+				continue
+			}
+
+			if name == "dive" {
+				switch {
+				case target.Items != nil:
+					target = target.Items
+				case target.AdditionalProperties != nil:
+					target = target.AdditionalProperties
+				default:
+					target = &Schema{}
+				}
+				continue
+			}
+
+			if target.Ref == "" {
+				applyRule(target, name, param)
+			}
+		}
+	}
+
+	return required
+}
+
+// applyRule applies a single validator rule to a schema.
+func applyRule(schema *Schema, name, param string) {
+	if format, ok := validationFormats[name]; ok {
+		schema.Format = format
+		return
+	}
+
+	if pattern, ok := validationPatterns[name]; ok {
+		schema.Pattern = pattern
+		return
+	}
+
+	switch name {
+	case "min", "gte":
+		setLowerBound(schema, param, false)
+	case "gt":
+		setLowerBound(schema, param, true)
+	case "max", "lte":
+		setUpperBound(schema, param, false)
+	case "lt":
+		setUpperBound(schema, param, true)
+	case "len":
+		setLowerBound(schema, param, false)
+		setUpperBound(schema, param, false)
+	case "oneof":
+		schema.Enum = oneofValues(schema, param)
+	case "unique":
+		if schema.Type == "array" {
+			schema.UniqueItems = true
+		}
+	case "startswith":
+		schema.Pattern = "^" + regexp.QuoteMeta(param)
+	case "endswith":
+		schema.Pattern = regexp.QuoteMeta(param) + "$"
+	case "contains":
+		schema.Pattern = regexp.QuoteMeta(param)
+	}
+}
+
+// setLowerBound sets the minimum, minLength or minItems of a schema
+// depending on its type.
+func setLowerBound(schema *Schema, param string, exclusive bool) {
+	switch schema.Type {
+	case "string":
+		if n, err := strconv.Atoi(param); err == nil {
+			if exclusive {
+				n++
+			}
+			schema.MinLength = &n
+		}
+	case "array":
+		if n, err := strconv.Atoi(param); err == nil {
+			if exclusive {
+				n++
+			}
+			schema.MinItems = &n
+		}
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(param, 64); err == nil {
+			schema.Minimum = &f
+			schema.ExclusiveMinimum = exclusive
+		}
+	}
+}
+
+// setUpperBound sets the maximum, maxLength or maxItems of a schema
+// depending on its type.
+func setUpperBound(schema *Schema, param string, exclusive bool) {
+	switch schema.Type {
+	case "string":
+		if n, err := strconv.Atoi(param); err == nil {
+			if exclusive {
+				n--
+			}
+			schema.MaxLength = &n
+		}
+	case "array":
+		if n, err := strconv.Atoi(param); err == nil {
+			if exclusive {
+				n--
+			}
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(param, 64); err == nil {
+			schema.Maximum = &f
+			schema.ExclusiveMaximum = exclusive
+		}
+	}
+}
+
+// oneofValues parses the values of a oneof rule, typed after the schema.
+func oneofValues(schema *Schema, param string) []interface{} {
+	var values []interface{}
+
+	for _, raw := range oneofPattern.FindAllString(param, -1) {
+		raw = strings.Trim(raw, "'")
+		values = append(values, typedValue(schema.Type, raw))
+	}
+
+	return values
+}
+
+// typedValue converts a literal to the Go value matching an OpenAPI type,
+// keeping the string when it does not parse.
+func typedValue(typ, raw string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
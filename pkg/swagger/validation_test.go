@@ -0,0 +1,88 @@
+package swagger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyValidation_Required(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		want bool
+	}{
+		{name: "binding required", tag: `binding:"required"`, want: true},
+		{name: "validate required", tag: `validate:"required,email"`, want: true},
+		{name: "required after dive", tag: `binding:"dive,required"`, want: false},
+		{name: "no rules", tag: `json:"name"`, want: false},
+		{name: "skipped", tag: `validate:"-"`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &Schema{Type: "array", Items: &Schema{Type: "string"}}
+			assert.Equal(t, tt.want, applyValidation(schema, tt.tag))
+		})
+	}
+}
+
+func TestApplyValidation_StringRules(t *testing.T) {
+	schema := &Schema{Type: "string"}
+	applyValidation(schema, `binding:"required,min=1,max=100,email"`)
+
+	require.NotNil(t, schema.MinLength)
+	require.NotNil(t, schema.MaxLength)
+	assert.Equal(t, 1, *schema.MinLength)
+	assert.Equal(t, 100, *schema.MaxLength)
+	assert.Equal(t, "email", schema.Format)
+
+	schema = &Schema{Type: "string"}
+	applyValidation(schema, `validate:"len=6,numeric"`)
+	assert.Equal(t, 6, *schema.MinLength)
+	assert.Equal(t, 6, *schema.MaxLength)
+	assert.NotEmpty(t, schema.Pattern)
+}
+
+func TestApplyValidation_NumericRules(t *testing.T) {
+	schema := &Schema{Type: "integer"}
+	applyValidation(schema, `binding:"gt=0,lte=100"`)
+
+	require.NotNil(t, schema.Minimum)
+	require.NotNil(t, schema.Maximum)
+	assert.Equal(t, 0.0, *schema.Minimum)
+	assert.True(t, schema.ExclusiveMinimum)
+	assert.Equal(t, 100.0, *schema.Maximum)
+	assert.False(t, schema.ExclusiveMaximum)
+}
+
+func TestApplyValidation_Oneof(t *testing.T) {
+	schema := &Schema{Type: "string"}
+	applyValidation(schema, `binding:"oneof=asc desc 'not sorted'"`)
+	assert.Equal(t, []interface{}{"asc", "desc", "not sorted"}, schema.Enum)
+
+	schema = &Schema{Type: "integer"}
+	applyValidation(schema, `validate:"oneof=1 2 3"`)
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, schema.Enum)
+}
+
+func TestApplyValidation_Dive(t *testing.T) {
+	schema := &Schema{Type: "array", Items: &Schema{Type: "string"}}
+	applyValidation(schema, `binding:"min=1,unique,dive,uuid"`)
+
+	require.NotNil(t, schema.MinItems)
+	assert.Equal(t, 1, *schema.MinItems)
+	assert.True(t, schema.UniqueItems)
+	assert.Equal(t, "uuid", schema.Items.Format)
+	assert.Empty(t, schema.Format)
+}
+
+func TestApplyValidation_Ref(t *testing.T) {
+	schema := refSchema("Address")
+	required := applyValidation(schema, `binding:"required,min=1"`)
+
+	assert.True(t, required)
+	assert.Equal(t, refSchema("Address"), schema)
+}